@@ -0,0 +1,274 @@
+package qframe
+
+import (
+	"math"
+
+	"github.com/tobgu/qframe/config/cast"
+	"github.com/tobgu/qframe/internal/bcolumn"
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/ecolumn"
+	"github.com/tobgu/qframe/internal/fcolumn"
+	"github.com/tobgu/qframe/internal/icolumn"
+	"github.com/tobgu/qframe/internal/index"
+	"github.com/tobgu/qframe/internal/scolumn"
+	qfstrings "github.com/tobgu/qframe/internal/strings"
+	"github.com/tobgu/qframe/qerrors"
+	"github.com/tobgu/qframe/types"
+)
+
+// Cast converts col to dataType. See the cast package for available configuration options.
+//
+// Int, float, bool, string and enum columns can be cast to any of those types, the narrow int
+// and float types are cast like int and float columns. Columns of all types can be cast to string
+// and enum columns, using the same representation as ToCSV.
+//
+// Null values stay null. Conversions that may fail are:
+// float -> int - Floats with decimals and floats outside of the int range.
+// string/enum -> int/float/bool - Strings that cannot be parsed as the new type.
+// any -> enum - Values not in the enum values if these have been given.
+// Numbers are true when cast to bool if they are non zero, true and false are cast to 1 and 0.
+//
+// Time complexity O(n) where n = number of rows.
+func (qf QFrame) Cast(col string, dataType types.DataType, configFns ...cast.ConfigFunc) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	conf, err := cast.NewConfig(configFns)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	namedCol, ok := qf.columnsByName[col]
+	if !ok {
+		return qf.withErr(qerrors.New("Cast", unknownCol(col)))
+	}
+
+	if namedCol.DataType() == dataType && (dataType != types.Enum || conf.EnumValues == nil) {
+		return qf
+	}
+
+	if !validCastDefault(conf, dataType) {
+		return qf.withErr(qerrors.New("Cast", "invalid default value type %T for %s", conf.Default, dataType))
+	}
+
+	src, err := newCastSource(namedCol.Column, qf.index, dataType)
+	if err != nil {
+		return qf.withErr(qerrors.Propagate("Cast", err))
+	}
+
+	size := namedCol.Len()
+	var result column.Column
+	switch dataType {
+	case types.Int:
+		data := make([]*int, size)
+		def, _ := conf.Default.(int)
+		err = castRows(src, conf, dataType, func(i int, pos uint32) bool {
+			x, ok := src.int(i)
+			if ok {
+				data[pos] = &x
+			}
+			return ok
+		}, func(pos uint32) { data[pos] = &def })
+		result = icolumn.NewPtrs(data)
+	case types.Float:
+		data := make([]float64, size)
+		for i := range data {
+			data[i] = math.NaN()
+		}
+		def, _ := conf.Default.(float64)
+		err = castRows(src, conf, dataType, func(i int, pos uint32) bool {
+			x, ok := src.float(i)
+			if ok {
+				data[pos] = x
+			}
+			return ok
+		}, func(pos uint32) { data[pos] = def })
+		result = fcolumn.New(data)
+	case types.Bool:
+		data := make([]*bool, size)
+		def, _ := conf.Default.(bool)
+		err = castRows(src, conf, dataType, func(i int, pos uint32) bool {
+			x, ok := src.bool(i)
+			if ok {
+				data[pos] = &x
+			}
+			return ok
+		}, func(pos uint32) { data[pos] = &def })
+		result = bcolumn.NewPtrs(data)
+	case types.String, types.Enum:
+		var valueSet qfstrings.StringSet
+		if dataType == types.Enum && conf.EnumValues != nil {
+			valueSet = qfstrings.NewStringSet(conf.EnumValues)
+		}
+
+		data := make([]*string, size)
+		def, _ := conf.Default.(string)
+		err = castRows(src, conf, dataType, func(i int, pos uint32) bool {
+			x := src.string(i)
+			if valueSet != nil && !valueSet.Contains(x) {
+				return false
+			}
+			data[pos] = &x
+			return true
+		}, func(pos uint32) { data[pos] = &def })
+
+		if dataType == types.String {
+			result = scolumn.New(data)
+		} else if err == nil {
+			result, err = ecolumn.New(data, conf.EnumValues)
+		}
+	default:
+		return qf.withErr(qerrors.New("Cast", "cannot cast to %s", dataType))
+	}
+
+	if err != nil {
+		return qf.withErr(qerrors.Propagate("Cast", err))
+	}
+
+	return qf.setColumn(col, result)
+}
+
+// validCastDefault returns false if a default value is used and it is not of the Go type corresponding to dataType.
+func validCastDefault(conf cast.Config, dataType types.DataType) bool {
+	if conf.OnFailure != "default" {
+		return true
+	}
+
+	var ok bool
+	switch dataType {
+	case types.Int:
+		_, ok = conf.Default.(int)
+	case types.Float:
+		_, ok = conf.Default.(float64)
+	case types.Bool:
+		_, ok = conf.Default.(bool)
+	case types.String, types.Enum:
+		_, ok = conf.Default.(string)
+	}
+
+	return ok
+}
+
+// castRows calls convert for all non null values in src with the position in the index and the position in
+// the column. convert sets the converted value, if it fails to convert the value it returns false and the
+// failure policy is applied. setDefault sets the default value. Values not set are null.
+func castRows(src castSource, conf cast.Config, dataType types.DataType, convert func(i int, pos uint32) bool, setDefault func(pos uint32)) error {
+	for i, pos := range src.ix {
+		if src.nulls[i] {
+			continue
+		}
+
+		if convert(i, pos) {
+			continue
+		}
+
+		switch conf.OnFailure {
+		case "error":
+			return qerrors.New("castRows", `cannot cast "%s" at row %d to %s`, src.col.StringAt(pos, "null"), i, dataType)
+		case "default":
+			setDefault(pos)
+		}
+	}
+
+	return nil
+}
+
+// castSource holds the values of a column to cast, in index order, using the type closest to the column type.
+type castSource struct {
+	col      column.Column
+	ix       index.Int
+	dataType types.DataType
+	nulls    index.Bool
+	ints     []int
+	floats   []float64
+	bools    []bool
+	strings  []*string
+}
+
+func newCastSource(col column.Column, ix index.Int, dataType types.DataType) (castSource, error) {
+	nulls, err := nullPositions(col, ix)
+	if err != nil {
+		return castSource{}, err
+	}
+
+	src := castSource{col: col, ix: ix, nulls: nulls, dataType: col.DataType()}
+	switch c := column.Widen(col).(type) {
+	case icolumn.Column:
+		src.dataType, src.ints = types.Int, c.View(ix).Slice()
+	case fcolumn.Column:
+		src.dataType, src.floats = types.Float, c.View(ix).Slice()
+	case bcolumn.Column:
+		src.bools = c.View(ix).Slice()
+	case scolumn.Column:
+		src.strings = c.View(ix).Slice()
+	case ecolumn.Column:
+		src.strings = c.View(ix).Slice()
+	default:
+		if dataType != types.String && dataType != types.Enum {
+			return castSource{}, qerrors.New("castSource", "cannot cast %s to %s", col.DataType(), dataType)
+		}
+	}
+
+	return src, nil
+}
+
+func (s castSource) int(i int) (int, bool) {
+	switch s.dataType {
+	case types.Int:
+		return s.ints[i], true
+	case types.Float:
+		f := s.floats[i]
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, false
+		}
+		return int(f), true
+	case types.Bool:
+		if s.bools[i] {
+			return 1, true
+		}
+		return 0, true
+	default:
+		x, err := qfstrings.ParseInt([]byte(*s.strings[i]))
+		return x, err == nil
+	}
+}
+
+func (s castSource) float(i int) (float64, bool) {
+	switch s.dataType {
+	case types.Int:
+		return float64(s.ints[i]), true
+	case types.Float:
+		return s.floats[i], true
+	case types.Bool:
+		if s.bools[i] {
+			return 1, true
+		}
+		return 0, true
+	default:
+		x, err := qfstrings.ParseFloat([]byte(*s.strings[i]))
+		return x, err == nil
+	}
+}
+
+func (s castSource) bool(i int) (bool, bool) {
+	switch s.dataType {
+	case types.Int:
+		return s.ints[i] != 0, true
+	case types.Float:
+		return s.floats[i] != 0, true
+	case types.Bool:
+		return s.bools[i], true
+	default:
+		x, err := qfstrings.ParseBool([]byte(*s.strings[i]))
+		return x, err == nil
+	}
+}
+
+func (s castSource) string(i int) string {
+	if s.strings != nil {
+		return *s.strings[i]
+	}
+
+	return s.col.StringAt(s.ix[i], "")
+}
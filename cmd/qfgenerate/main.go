@@ -8,11 +8,13 @@ import (
 	"os"
 
 	bgenerator "github.com/tobgu/qframe/internal/bcolumn"
+	dgenerator "github.com/tobgu/qframe/internal/dcolumn"
 	egenerator "github.com/tobgu/qframe/internal/ecolumn"
 	fgenerator "github.com/tobgu/qframe/internal/fcolumn"
 	igenerator "github.com/tobgu/qframe/internal/icolumn"
 	qfgenerator "github.com/tobgu/qframe/internal/qframe/generator"
 	sgenerator "github.com/tobgu/qframe/internal/scolumn"
+	tgenerator "github.com/tobgu/qframe/internal/tcolumn"
 	"github.com/tobgu/qframe/qerrors"
 )
 
@@ -40,6 +42,10 @@ func main() {
 		"efilter": egenerator.GenerateFilters,
 		"sdoc":    sgenerator.GenerateDoc,
 		"sfilter": sgenerator.GenerateFilters,
+		"tdoc":    tgenerator.GenerateDoc,
+		"tfilter": tgenerator.GenerateFilters,
+		"ddoc":    dgenerator.GenerateDoc,
+		"dfilter": dgenerator.GenerateFilters,
 		"qframe":  qfgenerator.GenerateQFrame,
 	}
 
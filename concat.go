@@ -0,0 +1,148 @@
+package qframe
+
+import (
+	"fmt"
+
+	"github.com/tobgu/qframe/config/concat"
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/index"
+	"github.com/tobgu/qframe/qerrors"
+	"github.com/tobgu/qframe/types"
+)
+
+func concatType(name string, frames []QFrame, conf concat.Config) (types.DataType, error) {
+	result := types.Undefined
+	for _, qf := range frames {
+		col, ok := qf.columnsByName[name]
+		if !ok {
+			continue
+		}
+
+		dataType := col.DataType()
+		switch {
+		case dataType == types.Undefined || dataType == result:
+		case result == types.Undefined:
+			result = dataType
+		case conf.PromoteInt && (result == types.Int && dataType == types.Float || result == types.Float && dataType == types.Int):
+			result = types.Float
+		default:
+			return result, qerrors.New("Concat", "type mismatch for column %s, %s != %s", name, result, dataType)
+		}
+	}
+
+	return result, nil
+}
+
+func concatColumn(name string, frames []QFrame, conf concat.Config) (column.Column, error) {
+	dataType, err := concatType(name, frames, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pick a column to base any null columns on
+	var refCol column.Column
+	for _, qf := range frames {
+		if col, ok := qf.columnsByName[name]; ok && (refCol == nil || col.DataType() != types.Undefined) {
+			refCol = col.Column
+		}
+	}
+
+	parts := make([]column.Column, 0, len(frames))
+	for _, qf := range frames {
+		if qf.Len() == 0 {
+			continue
+		}
+
+		var part column.Column
+		if col, ok := qf.columnsByName[name]; ok {
+			part = col.Subset(qf.index)
+		} else {
+			nullIx := make(index.Int, qf.Len())
+			for i := range nullIx {
+				nullIx[i] = nullRow
+			}
+
+			if part, err = nullableSubset(refCol, nullIx); err != nil {
+				return nil, qerrors.Propagate(fmt.Sprintf("Concat column %s", name), err)
+			}
+		}
+
+		if part.DataType() == types.Int && dataType == types.Float {
+			part = intToFloat(part)
+		}
+		parts = append(parts, part)
+	}
+
+	if len(parts) == 0 {
+		return refCol.Subset(index.Int{}), nil
+	}
+
+	result, err := parts[0].Append(parts[1:]...)
+	if err != nil {
+		return nil, qerrors.Propagate(fmt.Sprintf("Concat column %s", name), err)
+	}
+
+	return result, nil
+}
+
+// Concat returns a new QFrame with the rows of all frames stacked on top of each other, in order.
+//
+// The resulting QFrame contains the columns of the first frame followed by any columns only present in
+// subsequent frames, in the order they first appear. Rows from frames lacking a column are set to null
+// in that column.
+//
+// Columns must have the same type in all frames with the exception of int and float columns that are
+// combined into float columns if the concat.PromoteInt option is set. Enum columns with differing value
+// sets are merged into a column with the union of the value sets as long as the max cardinality is not
+// exceeded.
+//
+// Use ConcatWith to pass configuration options.
+//
+// Time complexity O(m * n) where m = number of columns, n = total number of rows.
+func Concat(frames ...QFrame) QFrame {
+	return ConcatWith(nil, frames...)
+}
+
+// ConcatWith works like Concat but takes configuration options. See the concat package for the
+// available options.
+//
+// Time complexity O(m * n) where m = number of columns, n = total number of rows.
+func ConcatWith(configFns []concat.ConfigFunc, frames ...QFrame) QFrame {
+	if len(frames) == 0 {
+		return QFrame{Err: qerrors.New("Concat", "no frames to concatenate")}
+	}
+
+	for _, qf := range frames {
+		if qf.Err != nil {
+			return QFrame{Err: qerrors.Propagate("Concat", qf.Err)}
+		}
+	}
+
+	conf := concat.NewConfig(configFns)
+	names := make([]string, 0, len(frames[0].columns))
+	seen := make(map[string]struct{}, len(frames[0].columns))
+	length := 0
+	for _, qf := range frames {
+		for _, col := range qf.columns {
+			if _, ok := seen[col.name]; !ok {
+				seen[col.name] = struct{}{}
+				names = append(names, col.name)
+			}
+		}
+		length += qf.Len()
+	}
+
+	newColumns := make([]namedColumn, len(names))
+	newColumnsByName := make(map[string]namedColumn, len(names))
+	for i, name := range names {
+		col, err := concatColumn(name, frames, conf)
+		if err != nil {
+			return QFrame{Err: err}
+		}
+
+		newColumns[i] = namedColumn{Column: col, name: name, pos: i}
+		newColumnsByName[name] = newColumns[i]
+	}
+
+	return QFrame{columns: newColumns, columnsByName: newColumnsByName, index: index.NewAscending(uint32(length))}
+}
@@ -0,0 +1,64 @@
+package cast
+
+import "github.com/tobgu/qframe/qerrors"
+
+// Config holds configuration for casting columns to other types.
+// It should be considered a private implementation detail and should never be
+// referenced or used directly outside of the QFrame code. To manipulate it
+// use the functions returning ConfigFunc below.
+type Config struct {
+	OnFailure  string // error/null/default
+	Default    interface{}
+	EnumValues []string
+}
+
+// ConfigFunc is a function that operates on a Config object.
+type ConfigFunc func(c *Config)
+
+// NewConfig creates a new Config object.
+// This function should never be called from outside QFrame.
+func NewConfig(ff []ConfigFunc) (Config, error) {
+	c := Config{OnFailure: "error"}
+	for _, fn := range ff {
+		fn(&c)
+	}
+
+	if c.OnFailure != "error" && c.OnFailure != "null" && c.OnFailure != "default" {
+		return c, qerrors.New("Cast config", "OnFailure must be error/null/default, was %s", c.OnFailure)
+	}
+
+	if c.OnFailure == "default" && c.Default == nil {
+		return c, qerrors.New("Cast config", "Default value must be set when OnFailure is default")
+	}
+
+	return c, nil
+}
+
+// OnFailure sets what to do with values that cannot be converted to the new type, eg. strings
+// that cannot be parsed as numbers or floats with decimals cast to int:
+// error - Cast fails with an error (default).
+// null - The values are converted to null.
+// default - The values are converted to the value set using Default.
+func OnFailure(policy string) ConfigFunc {
+	return func(c *Config) {
+		c.OnFailure = policy
+	}
+}
+
+// Default sets the value to use for values that cannot be converted and sets the failure
+// policy to default. The value must be of the Go type corresponding to the new column type,
+// int, float64, bool or string (string and enum).
+func Default(v interface{}) ConfigFunc {
+	return func(c *Config) {
+		c.Default = v
+		c.OnFailure = "default"
+	}
+}
+
+// EnumValues sets the allowed values, in order, when casting to an enum column. Values
+// not in the list count as conversion failures. If not set the values are taken from the data.
+func EnumValues(values ...string) ConfigFunc {
+	return func(c *Config) {
+		c.EnumValues = values
+	}
+}
@@ -0,0 +1,32 @@
+package concat
+
+// Config holds configuration for concatenation of QFrames.
+// It should be considered a private implementation detail and should never be
+// referenced or used directly outside of the QFrame code. To manipulate it
+// use the functions returning ConfigFunc below.
+type Config struct {
+	PromoteInt bool
+}
+
+// ConfigFunc is a function that operates on a Config object.
+type ConfigFunc func(c *Config)
+
+// NewConfig creates a new Config object.
+// This function should never be called from outside QFrame.
+func NewConfig(configFns []ConfigFunc) Config {
+	var config Config
+	for _, f := range configFns {
+		f(&config)
+	}
+
+	return config
+}
+
+// PromoteInt configures if int columns should be converted to float columns when the
+// column is an int column in some QFrames and a float column in others.
+// Default is false (eg. differing types result in an error).
+func PromoteInt(b bool) ConfigFunc {
+	return func(c *Config) {
+		c.PromoteInt = b
+	}
+}
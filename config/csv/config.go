@@ -1,6 +1,8 @@
 package csv
 
 import (
+	"time"
+
 	qfio "github.com/tobgu/qframe/internal/io"
 	"github.com/tobgu/qframe/types"
 )
@@ -17,7 +19,7 @@ type ConfigFunc func(*Config)
 // NewConfig creates a new Config object.
 // This function should never be called from outside QFrame.
 func NewConfig(ff []ConfigFunc) Config {
-	conf := Config{Delimiter: ','}
+	conf := Config{Delimiter: ',', TimeLayout: time.RFC3339Nano, TimeLocation: time.UTC}
 	for _, f := range ff {
 		f(&conf)
 	}
@@ -33,6 +35,17 @@ func EmptyNull(emptyNull bool) ConfigFunc {
 	}
 }
 
+// InferNullable configures if columns with empty fields may be auto detected as int and bool columns
+// with null values. By default such columns are detected as float columns, with null represented by
+// NaN, and string columns. Explicitly typed int and bool columns always accept empty fields as null.
+//
+// inferNullable - If set to true int and bool columns with null values will be detected.
+func InferNullable(inferNullable bool) ConfigFunc {
+	return func(c *Config) {
+		c.InferNullable = inferNullable
+	}
+}
+
 // IgnoreEmptyLines configures if a line without any characters should be ignored or interpreted
 // as a zero length string.
 //
@@ -103,3 +116,25 @@ func Headers(headers []string) ConfigFunc {
 		c.Headers = headers
 	}
 }
+
+// TimeLayout configures the layout used to parse the values of time columns, see time.Parse
+// for a description of layouts. Default is time.RFC3339Nano.
+//
+// Note that the column must be listed as having a time type (using Types above) for this option to take effect.
+//
+// layout - The layout to use.
+func TimeLayout(layout string) ConfigFunc {
+	return func(c *Config) {
+		c.TimeLayout = layout
+	}
+}
+
+// TimeLocation configures the location of time columns. Values without time zone information
+// are interpreted as being in this location. Default is UTC.
+//
+// location - The location to use.
+func TimeLocation(location *time.Location) ConfigFunc {
+	return func(c *Config) {
+		c.TimeLocation = location
+	}
+}
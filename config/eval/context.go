@@ -5,7 +5,9 @@ import (
 	"math"
 	"reflect"
 	"strings"
+	"time"
 
+	"github.com/tobgu/qframe/decimal"
 	"github.com/tobgu/qframe/function"
 	qfstrings "github.com/tobgu/qframe/internal/strings"
 	"github.com/tobgu/qframe/qerrors"
@@ -102,6 +104,21 @@ func NewDefaultCtx() *Context {
 					"+": function.ConcatS,
 				},
 			},
+			types.FunctionTypeTime: functionsByArgCount{
+				singleArgs: map[string]interface{}{
+					"str": function.StrT,
+					"int": function.IntT,
+					"utc": function.UTCT,
+				},
+				doubleArgs: map[string]interface{}{},
+			},
+			types.FunctionTypeDecimal: functionsByArgCount{
+				singleArgs: map[string]interface{}{
+					"str":   function.StrD,
+					"float": function.FloatD,
+				},
+				doubleArgs: map[string]interface{}{},
+			},
 		},
 	}
 }
@@ -170,6 +187,18 @@ func (ctx *Context) SetFunc(name string, fn interface{}) error {
 	case func(*string) *string, func(*string) int, func(*string) float64, func(*string) bool:
 		ac, typ = ArgCountOne, types.FunctionTypeString
 
+	// Time
+	case func(time.Time, time.Time) time.Time:
+		ac, typ = ArgCountTwo, types.FunctionTypeTime
+	case func(time.Time) time.Time, func(time.Time) int, func(time.Time) float64, func(time.Time) bool, func(time.Time) *string:
+		ac, typ = ArgCountOne, types.FunctionTypeTime
+
+	// Decimal
+	case func(decimal.Decimal, decimal.Decimal) decimal.Decimal:
+		ac, typ = ArgCountTwo, types.FunctionTypeDecimal
+	case func(decimal.Decimal) decimal.Decimal, func(decimal.Decimal) int, func(decimal.Decimal) float64, func(decimal.Decimal) bool, func(decimal.Decimal) *string:
+		ac, typ = ArgCountOne, types.FunctionTypeDecimal
+
 	default:
 		return qerrors.New("SetFunc", "invalid function type for function \"%s\": %v", name, reflect.TypeOf(fn))
 	}
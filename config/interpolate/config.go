@@ -0,0 +1,62 @@
+package interpolate
+
+import "github.com/tobgu/qframe/qerrors"
+
+// Config holds configuration for interpolation of missing values in QFrames.
+// It should be considered a private implementation detail and should never be
+// referenced or used directly outside of the QFrame code. To manipulate it
+// use the functions returning ConfigFunc below.
+type Config struct {
+	Method   string // linear/nearest/previous
+	XColName string
+	MaxGap   int
+}
+
+// ConfigFunc is a function that operates on a Config object.
+type ConfigFunc func(c *Config)
+
+// NewConfig creates a new Config object.
+// This function should never be called from outside QFrame.
+func NewConfig(ff []ConfigFunc) (Config, error) {
+	c := Config{Method: "linear"}
+	for _, fn := range ff {
+		fn(&c)
+	}
+
+	if c.Method != "linear" && c.Method != "nearest" && c.Method != "previous" {
+		return c, qerrors.New("Interpolate config", "Method must be linear/nearest/previous, was %s", c.Method)
+	}
+
+	if c.MaxGap < 0 {
+		return c, qerrors.New("Interpolate config", "Max gap must not be negative, was %d", c.MaxGap)
+	}
+
+	return c, nil
+}
+
+// Method sets the interpolation method:
+// linear - Values on the straight line between the closest known values before and after the gap (default).
+// nearest - The closest known value, with respect to the x axis, before or after the gap. Ties use the value before.
+// previous - The closest known value before the gap.
+func Method(m string) ConfigFunc {
+	return func(c *Config) {
+		c.Method = m
+	}
+}
+
+// XColumn sets an int or float column to use as x axis, eg. timestamps of irregularly sampled
+// measurements. The values are expected to be increasing in the order of the QFrame.
+// If no x column is given the rows are assumed to be evenly spaced.
+func XColumn(colName string) ConfigFunc {
+	return func(c *Config) {
+		c.XColName = colName
+	}
+}
+
+// MaxGap sets the maximum number of consecutive missing values to fill.
+// Longer gaps are left untouched. Default is 0 which means no limit.
+func MaxGap(n int) ConfigFunc {
+	return func(c *Config) {
+		c.MaxGap = n
+	}
+}
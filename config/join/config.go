@@ -0,0 +1,85 @@
+package join
+
+import "github.com/tobgu/qframe/qerrors"
+
+// Config holds configuration for join operations between QFrames.
+// It should be considered a private implementation detail and should never be
+// referenced or used directly outside of the QFrame code. To manipulate it
+// use the functions returning ConfigFunc below.
+type Config struct {
+	Columns     []string
+	How         string // inner/left/right/outer
+	LeftSuffix  string
+	RightSuffix string
+	JoinOnNull  bool
+}
+
+// ConfigFunc is a function that operates on a Config object.
+type ConfigFunc func(c *Config)
+
+// NewConfig creates a new Config object.
+// This function should never be called from outside QFrame.
+func NewConfig(ff []ConfigFunc) (Config, error) {
+	c := Config{
+		How:         "inner",
+		LeftSuffix:  "_left",
+		RightSuffix: "_right",
+	}
+
+	for _, fn := range ff {
+		fn(&c)
+	}
+
+	if c.How != "inner" && c.How != "left" && c.How != "right" && c.How != "outer" {
+		return c, qerrors.New("Join config", "How must be inner/left/right/outer, was %s", c.How)
+	}
+
+	if c.LeftSuffix == c.RightSuffix {
+		return c, qerrors.New("Join config", "Left and right suffix must differ, both were %s", c.LeftSuffix)
+	}
+
+	return c, nil
+}
+
+// Columns sets the key columns on which the QFrames should be joined. The columns
+// must exist in both QFrames and have the same type in both.
+// Leaving this configuration option out will join on all columns that the QFrames
+// have in common.
+func Columns(columns ...string) ConfigFunc {
+	return func(c *Config) {
+		c.Columns = columns
+	}
+}
+
+// How sets the type of join.
+// Valid values:
+// inner - Only rows with a matching key in both QFrames are kept.
+// left - All rows from the left QFrame are kept.
+// right - All rows from the right QFrame are kept.
+// outer - All rows from both QFrames are kept.
+// Default value: inner
+//
+// Values missing in the result because of an unmatched row are set to null.
+func How(h string) ConfigFunc {
+	return func(c *Config) {
+		c.How = h
+	}
+}
+
+// Suffixes sets the suffixes added to the names of non key columns that exist in
+// both QFrames.
+// Default values: _left, _right
+func Suffixes(left, right string) ConfigFunc {
+	return func(c *Config) {
+		c.LeftSuffix = left
+		c.RightSuffix = right
+	}
+}
+
+// Null configures if Na/nulls in key columns should match each other or not.
+// Default is false (eg. null/NaN never matches anything).
+func Null(b bool) ConfigFunc {
+	return func(c *Config) {
+		c.JoinOnNull = b
+	}
+}
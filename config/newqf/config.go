@@ -5,8 +5,10 @@ package newqf
 // referenced or used directly outside of the QFrame code. To manipulate it
 // use the functions returning ConfigFunc below.
 type Config struct {
-	ColumnOrder []string
-	EnumColumns map[string][]string
+	ColumnOrder    []string
+	EnumColumns    map[string][]string
+	DecimalColumns map[string]struct{}
+	InferInts      bool
 }
 
 // ConfigFunc is a function that operates on a Config object.
@@ -43,3 +45,24 @@ func Enums(columns map[string][]string) ConfigFunc {
 		}
 	}
 }
+
+// Decimals lists columns that should be considered decimals. The columns are expected to
+// contain the textual representation of the decimals, eg. "12.50", or be numbers in JSON input.
+// The scale of each column is the largest number of decimals found in it.
+func Decimals(columns ...string) ConfigFunc {
+	return func(c *Config) {
+		c.DecimalColumns = make(map[string]struct{}, len(columns))
+		for _, col := range columns {
+			c.DecimalColumns[col] = struct{}{}
+		}
+	}
+}
+
+// InferInts controls the column types of numbers read from JSON. If set numeric columns holding
+// only integers become int columns, null values included. Otherwise, which is the default, all
+// numeric columns become float columns with null represented by NaN.
+func InferInts(infer bool) ConfigFunc {
+	return func(c *Config) {
+		c.InferInts = infer
+	}
+}
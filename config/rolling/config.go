@@ -8,6 +8,8 @@ type DataValue = interface{}
 // IntervalFunc is a function taking two parameters of the same DataValue and returning boolean stating if
 // the two values are part of the same interval or not.
 //
+// The position of windows defined by an interval function defaults to start, center is not allowed.
+//
 // For example, x and y within one unit from each other (with x assumed to be <= y):
 type IntervalFunc = interface{}
 
@@ -26,15 +28,18 @@ type Config struct {
 type ConfigFunc func(c *Config)
 
 func NewConfig(ff []ConfigFunc) (Config, error) {
-	c := Config{
-		WindowSize: 1,
-		Position:   "center",
-	}
-
+	c := Config{WindowSize: 1}
 	for _, fn := range ff {
 		fn(&c)
 	}
 
+	if c.Position == "" {
+		c.Position = "center"
+		if c.IntervalFunc != nil {
+			c.Position = "start"
+		}
+	}
+
 	if c.WindowSize <= 0 {
 		return c, qerrors.New("Rolling config", "Window size must be positive, was %d", c.WindowSize)
 	}
@@ -47,11 +52,16 @@ func NewConfig(ff []ConfigFunc) (Config, error) {
 		return c, qerrors.New("Rolling config", "Cannot set both interval function and window size")
 	}
 
+	if c.IntervalFunc != nil && c.Position == "center" {
+		return c, qerrors.New("Rolling config", "Position must be start/end when using an interval function")
+	}
+
 	return c, nil
 }
 
 // PadValue can be used to set the value to use in the beginning and/or end of the column to fill out any values
-// where fewer than WindowSize values are available.
+// where fewer than WindowSize values are available. If no pad value is set the rolling function is applied to
+// the values that are available.
 func PadValue(v DataValue) ConfigFunc {
 	return func(c *Config) {
 		c.PadValue = v
@@ -68,6 +78,9 @@ func PadValue(v DataValue) ConfigFunc {
 //
 // In this case:
 // col = "ts", fn = func(tsStart, tsEnd int) bool { return tsEnd < tsStart + int(time.Minute / time.Millisecond)}
+//
+// The position must be start or end when using an interval function, it defaults to start. With position end
+// the window instead extends backwards from the row until the first row that is not part of the interval.
 func IntervalFunction(colName string, fn IntervalFunc) ConfigFunc {
 	return func(c *Config) {
 		c.IntervalColName = colName
@@ -84,7 +97,7 @@ func WindowSize(s int) ConfigFunc {
 
 // Position is used to set where in window the resulting value should be inserted.
 // Valid values: start/center/end
-// Default value: center
+// Default value: center, start when using an interval function
 func Position(p string) ConfigFunc {
 	return func(c *Config) {
 		c.Position = p
@@ -0,0 +1,65 @@
+package sample
+
+// Config holds configuration for sampling QFrames.
+// It should be considered a private implementation detail and should never be
+// referenced or used directly outside of the QFrame code. To manipulate it
+// use the functions returning ConfigFunc below.
+type Config struct {
+	Fraction    float64
+	UseFraction bool
+	Replace     bool
+	Seed        int64
+	UseSeed     bool
+	Columns     []string
+}
+
+// ConfigFunc is a function that operates on a Config object.
+type ConfigFunc func(c *Config)
+
+// NewConfig creates a new Config object.
+// This function should never be called from outside QFrame.
+func NewConfig(configFns []ConfigFunc) Config {
+	var config Config
+	for _, f := range configFns {
+		f(&config)
+	}
+
+	return config
+}
+
+// Fraction sets the fraction of rows, rounded to the closest integer, to sample instead of a
+// fixed number of rows. When sampling per group the fraction is applied to each group.
+// The fraction must not be greater than 1 unless sampling with replacement.
+func Fraction(f float64) ConfigFunc {
+	return func(c *Config) {
+		c.Fraction = f
+		c.UseFraction = true
+	}
+}
+
+// Replace configures if rows should be sampled with replacement, in which case the same
+// row may occur several times in the sample. Default is false.
+func Replace(b bool) ConfigFunc {
+	return func(c *Config) {
+		c.Replace = b
+	}
+}
+
+// Seed sets the seed of the random number generator used for sampling. Sampling the same
+// QFrame using the same seed always produces the same result.
+// If left out a seed based on the current time is used.
+func Seed(seed int64) ConfigFunc {
+	return func(c *Config) {
+		c.Seed = seed
+		c.UseSeed = true
+	}
+}
+
+// GroupBy sets columns by which the rows should be grouped before sampling. The number of rows,
+// or the fraction, is then sampled from each group (stratified sampling). Null values are
+// grouped together.
+func GroupBy(columns ...string) ConfigFunc {
+	return func(c *Config) {
+		c.Columns = columns
+	}
+}
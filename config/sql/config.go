@@ -11,6 +11,9 @@ const (
 	// Int64ToBool casts an int64 type into a bool,
 	// useful for handling SQLite INT -> BOOL.
 	Int64ToBool
+	// TextToDecimal parses numbers into exact decimals,
+	// useful for handling NUMERIC and DECIMAL columns.
+	TextToDecimal
 )
 
 // CoercePair casts the scanned value in Column
@@ -24,6 +27,8 @@ func coerceFunc(cType coerceType) qsqlio.CoerceFunc {
 	switch cType {
 	case Int64ToBool:
 		return qsqlio.Int64ToBool
+	case TextToDecimal:
+		return qsqlio.TextToDecimal
 	}
 	return nil
 }
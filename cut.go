@@ -0,0 +1,181 @@
+package qframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/tobgu/qframe/filter"
+	"github.com/tobgu/qframe/internal/ecolumn"
+	"github.com/tobgu/qframe/internal/index"
+	"github.com/tobgu/qframe/internal/math/float"
+	"github.com/tobgu/qframe/qerrors"
+)
+
+// Cut discretises the numeric column srcCol into buckets defined by edges and stores the result
+// in the enum column dstCol. The values of the enum are the bucket labels in bucket order which
+// means that sorting and comparing the result orders the rows by bucket.
+//
+// edges must be strictly increasing and contain at least two elements. Bucket i holds the values
+// x where edges[i] < x <= edges[i+1], the first bucket also holds edges[0]. Values outside of
+// the edges, and null values, are null in the result.
+//
+// labels contains one label for each bucket. If labels is nil the buckets are labeled with their
+// interval, eg. "(1, 2.5]".
+//
+// Int, float and decimal columns, including the narrow variants, can be cut.
+//
+// Time complexity O(n * log(m)) where n = number of rows, m = number of buckets.
+func (qf QFrame) Cut(dstCol, srcCol string, edges []float64, labels []string) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	if len(edges) < 2 {
+		return qf.withErr(qerrors.New("Cut", "at least two edges required"))
+	}
+
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i-1] < edges[i]) {
+			return qf.withErr(qerrors.New("Cut", "edges must be strictly increasing: %v", edges))
+		}
+	}
+
+	return qf.cut("Cut", dstCol, srcCol, edges, labels)
+}
+
+// QCut works like Cut but with edges chosen so that the non null values of srcCol are divided
+// into buckets of (approximately) the same size. The edges are the quantiles 0, 1/buckets, ...,
+// 1 of the values, interpolated linearly between the closest values.
+//
+// An error is returned if the values are too few or too uneven to produce distinct edges.
+//
+// Time complexity O(n * log(n)) where n = number of rows.
+func (qf QFrame) QCut(dstCol, srcCol string, buckets int, labels []string) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	if buckets < 1 {
+		return qf.withErr(qerrors.New("QCut", "at least one bucket required"))
+	}
+
+	values, err := qf.cutValues("QCut", srcCol, false)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	if len(values) == 0 {
+		return qf.withErr(qerrors.New("QCut", "no values in column %s", srcCol))
+	}
+
+	sort.Float64s(values)
+	edges := make([]float64, buckets+1)
+	for i := range edges {
+		edges[i] = float.Quantile(values, float64(i)/float64(buckets))
+		if i > 0 && edges[i] == edges[i-1] {
+			return qf.withErr(qerrors.New("QCut", "duplicate bucket edge %v, try fewer buckets", edges[i]))
+		}
+	}
+
+	return qf.cut("QCut", dstCol, srcCol, edges, labels)
+}
+
+// cutValues returns the values of srcCol as floats in index order. Nulls are
+// included as NaN if withNulls is true, excluded otherwise.
+func (qf QFrame) cutValues(operation, srcCol string, withNulls bool) ([]float64, error) {
+	col, ok := qf.columnsByName[srcCol]
+	if !ok {
+		return nil, qerrors.New(operation, unknownCol(srcCol))
+	}
+
+	bIndex := index.NewBool(qf.Len())
+	if err := col.Filter(qf.index, filter.IsNull, nil, bIndex); err != nil {
+		return nil, qerrors.Propagate(operation, err)
+	}
+
+	values := floatValues(col.Column, qf.index)
+	if values == nil {
+		return nil, qerrors.New(operation, "cannot cut %s column %s", col.DataType(), srcCol)
+	}
+
+	result := values[:0]
+	for i, isNull := range bIndex {
+		if isNull {
+			if withNulls {
+				result = append(result, math.NaN())
+			}
+			continue
+		}
+		result = append(result, values[i])
+	}
+
+	return result, nil
+}
+
+func (qf QFrame) cut(operation, dstCol, srcCol string, edges []float64, labels []string) QFrame {
+	if labels == nil {
+		labels = make([]string, len(edges)-1)
+		for i := range labels {
+			open := "("
+			if i == 0 {
+				open = "["
+			}
+			labels[i] = fmt.Sprintf("%s%s, %s]", open, formatEdge(edges[i]), formatEdge(edges[i+1]))
+		}
+	}
+
+	if len(labels) != len(edges)-1 {
+		return qf.withErr(qerrors.New(operation, "%d labels given for %d buckets", len(labels), len(edges)-1))
+	}
+
+	seen := make(map[string]struct{}, len(labels))
+	for _, l := range labels {
+		if _, ok := seen[l]; ok {
+			return qf.withErr(qerrors.New(operation, "duplicate label: %s", l))
+		}
+		seen[l] = struct{}{}
+	}
+
+	values, err := qf.cutValues(operation, srcCol, true)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	factory, err := ecolumn.NewFactory(labels, qf.columnsByName[srcCol].Len())
+	if err != nil {
+		return qf.withErr(qerrors.Propagate(operation, err))
+	}
+
+	// Rows not part of the index are null, like in the results of Apply
+	buckets := make([]int, qf.columnsByName[srcCol].Len())
+	for i := range buckets {
+		buckets[i] = -1
+	}
+
+	for i, x := range values {
+		j := sort.SearchFloat64s(edges, x)
+		if j == 0 && x == edges[0] {
+			j = 1
+		}
+
+		if j > 0 && j < len(edges) {
+			buckets[qf.index[i]] = j - 1
+		}
+	}
+
+	for _, b := range buckets {
+		if b < 0 {
+			factory.AppendNil()
+		} else if err := factory.AppendString(labels[b]); err != nil {
+			return qf.withErr(qerrors.Propagate(operation, err))
+		}
+	}
+
+	return qf.setColumn(dstCol, factory.ToColumn())
+}
+
+func formatEdge(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,319 @@
+/*
+Package decimal contains an exact fixed point number type, used for the values of decimal columns.
+*/
+package decimal
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/tobgu/qframe/qerrors"
+)
+
+// MaxScale is the max number of digits after the decimal point in a Decimal.
+const MaxScale = 18
+
+var pow10 = [MaxScale + 1]int64{
+	1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
+
+// Decimal is an exact fixed point number. Its value is Unscaled * 10^-Scale.
+// Two decimals with different scales may represent the same value, eg. 1.5 and 1.50.
+//
+// The zero value represents 0.
+type Decimal struct {
+	unscaled int64
+	scale    int
+}
+
+// New creates a new Decimal with value unscaled * 10^-scale.
+// scale must be between 0 and MaxScale, values outside of that range are clamped.
+func New(unscaled int64, scale int) Decimal {
+	if scale < 0 {
+		scale = 0
+	} else if scale > MaxScale {
+		scale = MaxScale
+	}
+
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+// FromInt creates a new Decimal with scale 0 and value x.
+func FromInt(x int) Decimal {
+	return Decimal{unscaled: int64(x)}
+}
+
+// Parse parses a decimal number, eg. "-12.50", exactly. Exponents, eg. "1.5e3", are accepted
+// as long as the resulting scale is within the limits.
+func Parse(s string) (Decimal, error) {
+	mantissa, exp := s, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == 'e' || s[i] == 'E' {
+			e, err := strconv.Atoi(s[i+1:])
+			if err != nil {
+				return Decimal{}, qerrors.New("decimal.Parse", "invalid exponent in %q", s)
+			}
+			mantissa, exp = s[:i], e
+			break
+		}
+	}
+
+	negative := false
+	if len(mantissa) > 0 && (mantissa[0] == '-' || mantissa[0] == '+') {
+		negative = mantissa[0] == '-'
+		mantissa = mantissa[1:]
+	}
+
+	var unscaled uint64
+	digits, scale, seenPoint := 0, 0, false
+	for i := 0; i < len(mantissa); i++ {
+		c := mantissa[i]
+		if c == '.' && !seenPoint {
+			seenPoint = true
+			continue
+		}
+
+		if c < '0' || c > '9' {
+			return Decimal{}, qerrors.New("decimal.Parse", "invalid decimal %q", s)
+		}
+
+		if unscaled > (math.MaxInt64-uint64(c-'0'))/10 {
+			return Decimal{}, qerrors.New("decimal.Parse", "decimal %q out of range", s)
+		}
+
+		unscaled = 10*unscaled + uint64(c-'0')
+		digits++
+		if seenPoint {
+			scale++
+		}
+	}
+
+	if digits == 0 {
+		return Decimal{}, qerrors.New("decimal.Parse", "invalid decimal %q", s)
+	}
+
+	result := Decimal{unscaled: int64(unscaled), scale: scale}
+	if negative {
+		result.unscaled = -result.unscaled
+	}
+
+	if exp == 0 {
+		if scale > MaxScale {
+			return Decimal{}, qerrors.New("decimal.Parse", "too many decimals in %q, max scale is %d", s, MaxScale)
+		}
+		return result, nil
+	}
+
+	newScale := scale - exp
+	if newScale < 0 {
+		newScale = 0
+	}
+
+	if newScale > MaxScale || scale-exp < -MaxScale {
+		return Decimal{}, qerrors.New("decimal.Parse", "decimal %q out of range", s)
+	}
+
+	result.scale -= exp
+	if result.scale < 0 {
+		// Multiply up to scale 0
+		result.scale = 0
+		unscaled, ok := mul(result.unscaled, pow10[exp-scale])
+		if !ok {
+			return Decimal{}, qerrors.New("decimal.Parse", "decimal %q out of range", s)
+		}
+		result.unscaled = unscaled
+	}
+
+	return result, nil
+}
+
+// MustParse works like Parse but panics on invalid input.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Unscaled returns the unscaled value of d.
+func (d Decimal) Unscaled() int64 {
+	return d.unscaled
+}
+
+// Scale returns the number of digits after the decimal point in d.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// String returns d with exactly Scale digits after the decimal point.
+func (d Decimal) String() string {
+	return string(d.AppendBytes(nil))
+}
+
+// AppendBytes appends the string representation of d to buf.
+func (d Decimal) AppendBytes(buf []byte) []byte {
+	if d.scale == 0 {
+		return strconv.AppendInt(buf, d.unscaled, 10)
+	}
+
+	u := uint64(d.unscaled)
+	if d.unscaled < 0 {
+		buf = append(buf, '-')
+		u = uint64(-d.unscaled)
+	}
+
+	var digits [24]byte
+	s := strconv.AppendUint(digits[:0], u, 10)
+	if len(s) <= d.scale {
+		buf = append(buf, '0', '.')
+		for i := len(s); i < d.scale; i++ {
+			buf = append(buf, '0')
+		}
+		return append(buf, s...)
+	}
+
+	buf = append(buf, s[:len(s)-d.scale]...)
+	buf = append(buf, '.')
+	return append(buf, s[len(s)-d.scale:]...)
+}
+
+// Float64 returns the float closest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Rescale returns d with the given scale. An error is returned if the value of d
+// cannot be represented exactly using the new scale.
+func (d Decimal) Rescale(scale int) (Decimal, error) {
+	if scale < 0 || scale > MaxScale {
+		return Decimal{}, qerrors.New("decimal.Rescale", "invalid scale %d", scale)
+	}
+
+	if scale >= d.scale {
+		unscaled, ok := mul(d.unscaled, pow10[scale-d.scale])
+		if !ok {
+			return Decimal{}, qerrors.New("decimal.Rescale", "decimal %s out of range using scale %d", d, scale)
+		}
+		return Decimal{unscaled: unscaled, scale: scale}, nil
+	}
+
+	divisor := pow10[d.scale-scale]
+	if d.unscaled%divisor != 0 {
+		return Decimal{}, qerrors.New("decimal.Rescale", "decimal %s cannot be represented using scale %d", d, scale)
+	}
+
+	return Decimal{unscaled: d.unscaled / divisor, scale: scale}, nil
+}
+
+// Floor returns the largest unscaled value at the given scale that is less than or equal to d,
+// and true if that value equals d. If the result is out of range it is clamped to the int64 range.
+func (d Decimal) Floor(scale int) (int64, bool) {
+	if r, err := d.Rescale(scale); err == nil {
+		return r.unscaled, true
+	}
+
+	if scale >= d.scale {
+		// Out of range
+		if d.unscaled < 0 {
+			return math.MinInt64, false
+		}
+		return math.MaxInt64, false
+	}
+
+	divisor := pow10[d.scale-scale]
+	result := d.unscaled / divisor
+	if d.unscaled < 0 {
+		result--
+	}
+	return result, false
+}
+
+// Cmp compares d and other and returns -1 if d < other, 0 if d == other and 1 if d > other.
+func (d Decimal) Cmp(other Decimal) int {
+	x, y := d, other
+	if d.scale != other.scale {
+		var err1, err2 error
+		if d.scale > other.scale {
+			y, err2 = other.Rescale(d.scale)
+		} else {
+			x, err1 = d.Rescale(other.scale)
+		}
+
+		if err1 != nil || err2 != nil {
+			return d.bigCmp(other)
+		}
+	}
+
+	if x.unscaled < y.unscaled {
+		return -1
+	}
+
+	if x.unscaled > y.unscaled {
+		return 1
+	}
+
+	return 0
+}
+
+func (d Decimal) bigCmp(other Decimal) int {
+	scale := d.scale
+	if other.scale > scale {
+		scale = other.scale
+	}
+
+	x := new(big.Int).Mul(big.NewInt(d.unscaled), big.NewInt(pow10[scale-d.scale]))
+	y := new(big.Int).Mul(big.NewInt(other.unscaled), big.NewInt(pow10[scale-other.scale]))
+	return x.Cmp(y)
+}
+
+// Equal returns true if d and other represent the same value, regardless of scale.
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// Add returns d + other using the larger of the scales. An error is returned on overflow.
+func (d Decimal) Add(other Decimal) (Decimal, error) {
+	x, y, err := sameScale(d, other)
+	if err != nil {
+		return Decimal{}, qerrors.Propagate("decimal.Add", err)
+	}
+
+	sum := x.unscaled + y.unscaled
+	if (sum > x.unscaled) != (y.unscaled > 0) {
+		return Decimal{}, qerrors.New("decimal.Add", "overflow adding %s and %s", d, other)
+	}
+
+	return Decimal{unscaled: sum, scale: x.scale}, nil
+}
+
+// Sub returns d - other using the larger of the scales. An error is returned on overflow.
+func (d Decimal) Sub(other Decimal) (Decimal, error) {
+	if other.unscaled == math.MinInt64 {
+		return Decimal{}, qerrors.New("decimal.Sub", "overflow subtracting %s from %s", other, d)
+	}
+
+	return d.Add(Decimal{unscaled: -other.unscaled, scale: other.scale})
+}
+
+func sameScale(d1, d2 Decimal) (Decimal, Decimal, error) {
+	var err error
+	if d1.scale > d2.scale {
+		d2, err = d2.Rescale(d1.scale)
+	} else if d2.scale > d1.scale {
+		d1, err = d1.Rescale(d2.scale)
+	}
+
+	return d1, d2, err
+}
+
+// mul returns x * y and true if the multiplication did not overflow, y must be positive.
+func mul(x, y int64) (int64, bool) {
+	if x > math.MaxInt64/y || x < math.MinInt64/y {
+		return 0, false
+	}
+	return x * y, true
+}
@@ -0,0 +1,171 @@
+package qframe
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tobgu/qframe/config/newqf"
+	"github.com/tobgu/qframe/filter"
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/dcolumn"
+	"github.com/tobgu/qframe/internal/fcolumn"
+	"github.com/tobgu/qframe/internal/grouper"
+	"github.com/tobgu/qframe/internal/icolumn"
+	"github.com/tobgu/qframe/internal/index"
+	"github.com/tobgu/qframe/internal/math/float"
+	"github.com/tobgu/qframe/qerrors"
+	"github.com/tobgu/qframe/types"
+)
+
+// Describe returns a QFrame with summary statistics for the columns in the QFrame, one row per column
+// in the same order as the columns. The resulting QFrame has the following columns:
+//
+//	column         - The column name.
+//	type           - The column data type.
+//	count          - The number of non null values.
+//	null_count     - The number of null values.
+//	distinct_count - The number of distinct non null values.
+//	min, max, mean, std, 25%, 50%, 75%
+//	               - Minimum, maximum, mean, sample standard deviation and quartiles of the non null values.
+//	                 Only populated for numeric columns (int, float, decimal and their narrow variants),
+//	                 NaN for other columns. Time columns are left out since the statistics are floats.
+//	                 Quartiles are linearly interpolated between the closest values.
+//	top            - The most frequent non null value. Only populated for string, enum and bool columns.
+//	                 When several values are equally frequent the smallest of them is used.
+//	freq           - The number of occurrences of the top value.
+//
+// Time complexity O(m * n * log(n)) where m = number of columns, n = number of rows.
+func (qf QFrame) Describe() QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	count := len(qf.columns)
+	names, typs := make([]string, count), make([]string, count)
+	counts, nullCounts, distinctCounts := make([]int, count), make([]int, count), make([]int, count)
+	mins, maxs, means, stds := make([]float64, count), make([]float64, count), make([]float64, count), make([]float64, count)
+	q1s, q2s, q3s := make([]float64, count), make([]float64, count), make([]float64, count)
+	tops, freqs := make([]*string, count), make([]*int, count)
+
+	for i, col := range qf.columns {
+		names[i], typs[i] = col.name, string(col.DataType())
+		nonNull := qf.Filter(Filter{Column: col.name, Comparator: filter.IsNotNull})
+		if nonNull.Err != nil {
+			return qf.withErr(qerrors.Propagate("Describe", nonNull.Err))
+		}
+
+		counts[i], nullCounts[i] = nonNull.Len(), qf.Len()-nonNull.Len()
+		groups := distinctGroups(col.Column, nonNull.index)
+		distinctCounts[i] = len(groups)
+
+		stats := numericStats(col.Column, nonNull.index)
+		mins[i], maxs[i], means[i], stds[i] = stats.min, stats.max, stats.mean, stats.std
+		q1s[i], q2s[i], q3s[i] = stats.q1, stats.q2, stats.q3
+
+		switch col.DataType() {
+		case types.String, types.Enum, types.Bool:
+			if ix := mostFrequent(col.Column, groups); ix != nil {
+				top, freq := col.StringAt(ix[0], ""), len(ix)
+				tops[i], freqs[i] = &top, &freq
+			}
+		}
+	}
+
+	return New(map[string]interface{}{
+		"column":         names,
+		"type":           typs,
+		"count":          counts,
+		"null_count":     nullCounts,
+		"distinct_count": distinctCounts,
+		"min":            mins,
+		"max":            maxs,
+		"mean":           means,
+		"std":            stds,
+		"25%":            q1s,
+		"50%":            q2s,
+		"75%":            q3s,
+		"top":            tops,
+		"freq":           freqs,
+	}, newqf.ColumnOrder("column", "type", "count", "null_count", "distinct_count",
+		"min", "max", "mean", "std", "25%", "50%", "75%", "top", "freq"))
+}
+
+// distinctGroups returns the positions in ix grouped by value.
+func distinctGroups(col column.Column, ix index.Int) []index.Int {
+	if len(ix) == 0 {
+		return nil
+	}
+
+	groups, _ := grouper.GroupBy(ix, []column.Comparable{col.Comparable(false, false, false)})
+	return groups
+}
+
+// mostFrequent returns the largest group, ties are broken by choosing the group with the smallest value.
+func mostFrequent(col column.Column, groups []index.Int) index.Int {
+	comparable := col.Comparable(false, false, false)
+	var result index.Int
+	for _, g := range groups {
+		if len(g) > len(result) || (len(g) == len(result) && comparable.Compare(g[0], result[0]) == column.LessThan) {
+			result = g
+		}
+	}
+
+	return result
+}
+
+type describeStats struct {
+	min, max, mean, std, q1, q2, q3 float64
+}
+
+// numericStats calculates the statistics of the values at the positions in ix, which must not be null.
+// All statistics are NaN for non numeric columns and empty indices.
+func numericStats(col column.Column, ix index.Int) describeStats {
+	nan := math.NaN()
+	stats := describeStats{min: nan, max: nan, mean: nan, std: nan, q1: nan, q2: nan, q3: nan}
+	values := floatValues(col, ix)
+	if len(values) == 0 {
+		return stats
+	}
+
+	sort.Float64s(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	stats.mean = sum / float64(len(values))
+
+	if len(values) > 1 {
+		sqSum := 0.0
+		for _, v := range values {
+			sqSum += (v - stats.mean) * (v - stats.mean)
+		}
+		stats.std = math.Sqrt(sqSum / float64(len(values)-1))
+	}
+
+	stats.min, stats.max = values[0], values[len(values)-1]
+	stats.q1, stats.q2, stats.q3 = float.Quantile(values, 0.25), float.Quantile(values, 0.5), float.Quantile(values, 0.75)
+	return stats
+}
+
+// floatValues returns the values at the positions in ix as floats, nil if the column is not numeric.
+func floatValues(col column.Column, ix index.Int) []float64 {
+	switch c := column.Widen(col).(type) {
+	case icolumn.Column:
+		result := make([]float64, len(ix))
+		for i, x := range c.View(ix).Slice() {
+			result[i] = float64(x)
+		}
+		return result
+	case fcolumn.Column:
+		return c.View(ix).Slice()
+	case dcolumn.Column:
+		view := c.View(ix)
+		result := make([]float64, view.Len())
+		for i := range result {
+			result[i] = view.ItemAt(i).Float64()
+		}
+		return result
+	}
+
+	return nil
+}
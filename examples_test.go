@@ -10,6 +10,7 @@ import (
 	"github.com/tobgu/qframe/config/newqf"
 	"github.com/tobgu/qframe/function"
 	"github.com/tobgu/qframe/types"
+	"github.com/tobgu/qframe/window"
 )
 
 func ExampleQFrame_filterBuiltin() {
@@ -212,6 +213,28 @@ func ExampleQFrame_groupByAggregate() {
 	// Dims = 2 x 3
 }
 
+func ExampleGrouper_Window() {
+	f := qframe.New(map[string]interface{}{
+		"COL1": []string{"a", "b", "a", "b", "a"},
+		"COL2": []int{3, 1, 1, 2, 2}},
+		newqf.ColumnOrder("COL1", "COL2"))
+	f = f.GroupBy(groupby.Columns("COL1")).Sort(qframe.Order{Column: "COL2"}).Window(
+		window.RowNumber("RN"),
+		window.CumSum("COL2", "CUM"))
+	fmt.Println(f)
+
+	// Output:
+	// COL1(s) COL2(i) RN(i) CUM(i)
+	// ------- ------- ----- ------
+	//       a       3     3      6
+	//       b       1     1      1
+	//       a       1     1      1
+	//       b       2     2      3
+	//       a       2     2      3
+	//
+	// Dims = 4 x 5
+}
+
 func ExampleQFrame_view() {
 	f := qframe.New(map[string]interface{}{"COL1": []int{1, 2, 3}})
 	v, _ := f.IntView("COL1")
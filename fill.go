@@ -0,0 +1,283 @@
+package qframe
+
+import (
+	"time"
+
+	"github.com/tobgu/qframe/decimal"
+	"github.com/tobgu/qframe/filter"
+	"github.com/tobgu/qframe/internal/bcolumn"
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/dcolumn"
+	"github.com/tobgu/qframe/internal/ecolumn"
+	"github.com/tobgu/qframe/internal/f32column"
+	"github.com/tobgu/qframe/internal/fcolumn"
+	"github.com/tobgu/qframe/internal/i16column"
+	"github.com/tobgu/qframe/internal/i32column"
+	"github.com/tobgu/qframe/internal/i8column"
+	"github.com/tobgu/qframe/internal/icolumn"
+	"github.com/tobgu/qframe/internal/index"
+	"github.com/tobgu/qframe/internal/scolumn"
+	"github.com/tobgu/qframe/internal/tcolumn"
+	"github.com/tobgu/qframe/internal/u16column"
+	"github.com/tobgu/qframe/internal/u32column"
+	"github.com/tobgu/qframe/internal/u8column"
+	"github.com/tobgu/qframe/qerrors"
+	"github.com/tobgu/qframe/types"
+)
+
+// FillNull returns a new QFrame where the null values of col, NaN for float columns, have been replaced by value.
+//
+// The type of value must match the column type: int, float64, bool, string (string and enum columns),
+// time.Time or decimal.Decimal. For the narrow int types int or the corresponding Go type may be used,
+// for float32 columns float64 or float32. Filling an enum column with fixed values with a value outside
+// of those is an error.
+//
+// Time complexity O(n) where n = number of rows.
+func (qf QFrame) FillNull(col string, value interface{}) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	namedCol, ok := qf.columnsByName[col]
+	if !ok {
+		return qf.withErr(qerrors.New("FillNull", unknownCol(col)))
+	}
+
+	valueCol, err := fillColumn(namedCol.Column, value)
+	if err != nil {
+		return qf.withErr(qerrors.Propagate("FillNull", err))
+	}
+
+	withValue, err := namedCol.Append(valueCol)
+	if err != nil {
+		return qf.withErr(qerrors.Propagate("FillNull", err))
+	}
+
+	ix := index.NewAscending(uint32(namedCol.Len()))
+	nulls, err := nullPositions(namedCol.Column, ix)
+	if err != nil {
+		return qf.withErr(qerrors.Propagate("FillNull", err))
+	}
+
+	valuePos := uint32(namedCol.Len())
+	for i, isNull := range nulls {
+		if isNull {
+			ix[i] = valuePos
+		}
+	}
+
+	return qf.setColumn(col, withValue.Subset(ix))
+}
+
+// fillColumn returns a column holding value only that can be appended to col.
+func fillColumn(col column.Column, value interface{}) (column.Column, error) {
+	switch col.DataType() {
+	case types.Int:
+		if v, ok := value.(int); ok {
+			return icolumn.New([]int{v}), nil
+		}
+	case types.Float:
+		if v, ok := value.(float64); ok {
+			return fcolumn.New([]float64{v}), nil
+		}
+	case types.Bool:
+		if v, ok := value.(bool); ok {
+			return bcolumn.New([]bool{v}), nil
+		}
+	case types.String:
+		if v, ok := value.(string); ok {
+			return scolumn.New([]*string{&v}), nil
+		}
+	case types.Enum:
+		if v, ok := value.(string); ok {
+			return col.(ecolumn.Column).Const(&v, 1)
+		}
+	case types.Time:
+		if v, ok := value.(time.Time); ok {
+			return tcolumn.New([]time.Time{v}), nil
+		}
+	case types.Decimal:
+		if v, ok := value.(decimal.Decimal); ok {
+			return dcolumn.New([]decimal.Decimal{v})
+		}
+	case types.Float32:
+		switch v := value.(type) {
+		case float32:
+			return f32column.New([]float32{v}), nil
+		case float64:
+			return f32column.FromFloats([]float64{v})
+		}
+	case types.Int8:
+		switch v := value.(type) {
+		case int8:
+			return i8column.New([]int8{v}), nil
+		case int:
+			return i8column.FromInts([]int{v}, nil)
+		}
+	case types.Int16:
+		switch v := value.(type) {
+		case int16:
+			return i16column.New([]int16{v}), nil
+		case int:
+			return i16column.FromInts([]int{v}, nil)
+		}
+	case types.Int32:
+		switch v := value.(type) {
+		case int32:
+			return i32column.New([]int32{v}), nil
+		case int:
+			return i32column.FromInts([]int{v}, nil)
+		}
+	case types.Uint8:
+		switch v := value.(type) {
+		case uint8:
+			return u8column.New([]uint8{v}), nil
+		case int:
+			return u8column.FromInts([]int{v}, nil)
+		}
+	case types.Uint16:
+		switch v := value.(type) {
+		case uint16:
+			return u16column.New([]uint16{v}), nil
+		case int:
+			return u16column.FromInts([]int{v}, nil)
+		}
+	case types.Uint32:
+		switch v := value.(type) {
+		case uint32:
+			return u32column.New([]uint32{v}), nil
+		case int:
+			return u32column.FromInts([]int{v}, nil)
+		}
+	}
+
+	return nil, qerrors.New("fillColumn", "invalid fill value type %T for %s column", value, col.DataType())
+}
+
+// nullPositions returns a bool index that is true for the positions in ix that are null in col.
+func nullPositions(col column.Column, ix index.Int) (index.Bool, error) {
+	bIndex := index.NewBool(len(ix))
+	if err := col.Filter(ix, filter.IsNull, nil, bIndex); err != nil {
+		return nil, err
+	}
+
+	return bIndex, nil
+}
+
+// FFill returns a new QFrame where null values in cols have been replaced with the closest
+// preceding non null value, in the order of the QFrame. Null values without any preceding non
+// null value are kept. If no columns are given all columns are filled.
+//
+// Use Grouper.FFill to fill values within groups.
+//
+// Time complexity O(m * n) where m = number of columns, n = number of rows.
+func (qf QFrame) FFill(cols ...string) QFrame {
+	return qf.fill("FFill", cols, []index.Int{qf.index}, false)
+}
+
+// BFill works like FFill but replaces null values with the closest following non null value.
+//
+// Time complexity O(m * n) where m = number of columns, n = number of rows.
+func (qf QFrame) BFill(cols ...string) QFrame {
+	return qf.fill("BFill", cols, []index.Int{qf.index}, true)
+}
+
+func (qf QFrame) fill(operation string, cols []string, indices []index.Int, backward bool) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	if err := qf.checkColumns(operation, cols); err != nil {
+		return qf.withErr(err)
+	}
+
+	result := qf
+	for _, colName := range qf.columnsOrAll(cols) {
+		col := qf.columnsByName[colName].Column
+		fillIx, err := fillIndex(col, indices, backward)
+		if err != nil {
+			return qf.withErr(qerrors.Propagate(operation, err))
+		}
+
+		result = result.setColumn(colName, col.Subset(fillIx))
+	}
+
+	return result
+}
+
+// fillIndex returns an index over all positions in col where the null positions in each of
+// indices point to the closest preceding, or following if backward, non null position in the
+// same index instead.
+func fillIndex(col column.Column, indices []index.Int, backward bool) (index.Int, error) {
+	result := index.NewAscending(uint32(col.Len()))
+	for _, ix := range indices {
+		nulls, err := nullPositions(col, ix)
+		if err != nil {
+			return nil, err
+		}
+
+		last := -1
+		for n := range ix {
+			i := n
+			if backward {
+				i = len(ix) - 1 - n
+			}
+
+			if !nulls[i] {
+				last = i
+			} else if last >= 0 {
+				result[ix[i]] = ix[last]
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// DropNull returns a new QFrame without the rows that are null in cols. If how is "any"
+// rows that are null in any of the columns are dropped, if it is "all" only rows that are
+// null in all of the columns are dropped. If no columns are given all columns are considered.
+//
+// Time complexity O(m * n) where m = number of columns, n = number of rows.
+func (qf QFrame) DropNull(how string, cols ...string) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	if how != "any" && how != "all" {
+		return qf.withErr(qerrors.New("DropNull", `how must be "any" or "all", was "%s"`, how))
+	}
+
+	if err := qf.checkColumns("DropNull", cols); err != nil {
+		return qf.withErr(err)
+	}
+
+	cols = qf.columnsOrAll(cols)
+	nullCounts := make([]int, qf.Len())
+	for _, colName := range cols {
+		nulls, err := nullPositions(qf.columnsByName[colName].Column, qf.index)
+		if err != nil {
+			return qf.withErr(qerrors.Propagate("DropNull", err))
+		}
+
+		for i, isNull := range nulls {
+			if isNull {
+				nullCounts[i]++
+			}
+		}
+	}
+
+	limit := 1
+	if how == "all" {
+		limit = len(cols)
+	}
+
+	newIx := make(index.Int, 0, qf.Len())
+	for i, count := range nullCounts {
+		if count < limit || len(cols) == 0 {
+			newIx = append(newIx, qf.index[i])
+		}
+	}
+
+	return qf.withIndex(newIx)
+}
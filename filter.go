@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/tobgu/qframe/filter"
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/grouper"
 	"github.com/tobgu/qframe/internal/index"
 	"github.com/tobgu/qframe/internal/math/integer"
 	"github.com/tobgu/qframe/qerrors"
@@ -265,6 +267,95 @@ func (c NotClause) Err() error {
 	return c.subClause.Err()
 }
 
+// InClause represents a filter that keeps rows whose key exist (or does not exist if inverted)
+// in another QFrame. This is sometimes referred to as a semi join (anti join if inverted).
+type InClause struct {
+	other   QFrame
+	columns []string
+	inverse bool
+}
+
+// In returns a new InClause that keeps the rows for which the values of the key columns
+// are found in a row of other. If no columns are given the columns common to both QFrames
+// are used as key columns. Key columns must have the same type in both QFrames.
+//
+// Null/NaN values never match any value.
+//
+// Time complexity O((m + n) * k) where m and n = number of rows in the two QFrames, k = number of key columns.
+func In(other QFrame, columns ...string) InClause {
+	return InClause{other: other, columns: columns}
+}
+
+// NotIn returns a new InClause that keeps the rows for which the values of the key columns
+// are not found in any row of other. See In for further details.
+func NotIn(other QFrame, columns ...string) InClause {
+	return InClause{other: other, columns: columns, inverse: true}
+}
+
+// String returns a textual description of the filter clause.
+func (c InClause) String() string {
+	if c.Err() != nil {
+		return c.Err().Error()
+	}
+
+	colReps := make([]string, 0, len(c.columns))
+	for _, col := range c.columns {
+		colReps = append(colReps, fmt.Sprintf(`"%s"`, col))
+	}
+
+	s := fmt.Sprintf(`["in", [%s]]`, strings.Join(colReps, ", "))
+	if c.inverse {
+		return fmt.Sprintf(`["!", %s]`, s)
+	}
+	return s
+}
+
+func (c InClause) filter(qf QFrame) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	if c.Err() != nil {
+		return qf.withErr(c.Err())
+	}
+
+	columns, err := qf.joinColumns("In", c.other, c.columns)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	keyColumns, err := qf.keyColumns("In", c.other, columns)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	comparables := make([]column.Comparable, len(keyColumns))
+	for i, col := range keyColumns {
+		comparables[i] = col.Comparable(false, false, false)
+	}
+
+	qfLen := uint32(qf.Len())
+	otherIx := make(index.Int, c.other.Len())
+	for i := range otherIx {
+		otherIx[i] = qfLen + uint32(i)
+	}
+
+	exists := grouper.Exists(index.NewAscending(qfLen), otherIx, comparables)
+	newIx := make(index.Int, 0, len(qf.index))
+	for i, ix := range qf.index {
+		if exists[i] != c.inverse {
+			newIx = append(newIx, ix)
+		}
+	}
+
+	return qf.withIndex(newIx)
+}
+
+// Err returns any error that may have occurred during creation of the filter
+func (c InClause) Err() error {
+	return c.other.Err
+}
+
 // Null returns a new NullClause
 func Null() NullClause {
 	return NullClause{}
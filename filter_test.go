@@ -38,6 +38,10 @@ func TestFilter_Success(t *testing.T) {
 		return f("COL1", "=", x)
 	}
 
+	other := qframe.New(map[string]interface{}{
+		"COL1": []int{2, 4, 6, 4},
+	})
+
 	table := []struct {
 		name     string
 		clause   qframe.FilterClause
@@ -106,6 +110,31 @@ func TestFilter_Success(t *testing.T) {
 			not(f("COL1", "<", 6)),
 			[]int{},
 		},
+		{
+			"In",
+			qframe.In(other),
+			[]int{2, 4},
+		},
+		{
+			"Not in",
+			qframe.NotIn(other, "COL1"),
+			[]int{1, 3, 5},
+		},
+		{
+			"Or with in",
+			or(eq(5), qframe.In(other)),
+			[]int{2, 4, 5},
+		},
+		{
+			"And with not in",
+			and(qframe.NotIn(other), f("COL1", ">", 1)),
+			[]int{3, 5},
+		},
+		{
+			"Not with in",
+			not(qframe.In(other)),
+			[]int{1, 3, 5},
+		},
 	}
 
 	for _, tc := range table {
@@ -133,6 +162,8 @@ func TestFilter_ErrorColumnDoesNotExist(t *testing.T) {
 		and(col1Gt3, and(col1Gt3, colGt3)),
 		or(and(col1Gt3, colGt3), col1Gt3),
 		or(and(col1Gt3, col1Gt3), colGt3),
+		qframe.In(input, "COL"),
+		or(col1Gt3, qframe.NotIn(input, "COL")),
 	}
 
 	for i, c := range table {
@@ -162,6 +193,8 @@ func TestFilter_String(t *testing.T) {
 			or(f("COL1", ">", 3), f("COL2", ">", 3)),
 			`["or", [">", "COL1", 3], [">", "COL2", 3]]`,
 		},
+		{qframe.In(qframe.QFrame{}, "COL1", "COL2"), `["in", ["COL1", "COL2"]]`},
+		{qframe.NotIn(qframe.QFrame{}, "COL1"), `["!", ["in", ["COL1"]]]`},
 	}
 
 	for _, tc := range table {
@@ -173,3 +206,57 @@ func TestFilter_String(t *testing.T) {
 		})
 	}
 }
+
+func TestFilter_InMultipleColumns(t *testing.T) {
+	a, b := "a", "b"
+	input := qframe.New(map[string]interface{}{
+		"COL1": []int{1, 1, 2, 2, 3},
+		"COL2": []*string{&a, &b, &a, nil, &b},
+		"COL3": []float64{1.5, 2.5, 3.5, 4.5, 5.5},
+	})
+
+	other := qframe.New(map[string]interface{}{
+		"COL1": []int{1, 2, 2},
+		"COL2": []*string{&b, &a, nil},
+		"COL4": []bool{true, false, true},
+	})
+
+	out := input.Filter(qframe.In(other))
+	assertNotErr(t, out.Err)
+	expected := qframe.New(map[string]interface{}{
+		"COL1": []int{1, 2},
+		"COL2": []string{"b", "a"},
+		"COL3": []float64{2.5, 3.5},
+	})
+	assertEquals(t, expected, out)
+
+	out = input.Filter(qframe.NotIn(other))
+	assertNotErr(t, out.Err)
+	expected = qframe.New(map[string]interface{}{
+		"COL1": []int{1, 2, 3},
+		"COL2": []*string{&a, nil, &b},
+		"COL3": []float64{1.5, 4.5, 5.5},
+	})
+	assertEquals(t, expected, out)
+}
+
+func TestFilter_InErrors(t *testing.T) {
+	input := qframe.New(map[string]interface{}{"COL1": []int{1, 2, 3}})
+
+	table := []struct {
+		name   string
+		clause qframe.FilterClause
+		err    string
+	}{
+		{"Type mismatch", qframe.In(qframe.New(map[string]interface{}{"COL1": []float64{1}})), "type mismatch"},
+		{"No common columns", qframe.In(qframe.New(map[string]interface{}{"COL2": []int{1}})), "no common columns"},
+		{"Error in other frame", qframe.In(input.Select("COL2")), "unknown column"},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			out := input.Filter(tc.clause)
+			assertErr(t, out.Err, tc.err)
+		})
+	}
+}
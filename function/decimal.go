@@ -0,0 +1,14 @@
+package function
+
+import "github.com/tobgu/qframe/decimal"
+
+// StrD returns the string representation of x with all decimals of the column.
+func StrD(x decimal.Decimal) *string {
+	result := x.String()
+	return &result
+}
+
+// FloatD returns the float closest to x.
+func FloatD(x decimal.Decimal) float64 {
+	return x.Float64()
+}
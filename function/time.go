@@ -0,0 +1,19 @@
+package function
+
+import "time"
+
+// StrT returns the RFC3339 representation of x, with nanoseconds.
+func StrT(x time.Time) *string {
+	result := x.Format(time.RFC3339Nano)
+	return &result
+}
+
+// IntT returns x as the number of nanoseconds elapsed since January 1, 1970 UTC.
+func IntT(x time.Time) int {
+	return int(x.UnixNano())
+}
+
+// UTCT returns x with the location set to UTC.
+func UTCT(x time.Time) time.Time {
+	return x.UTC()
+}
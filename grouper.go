@@ -1,9 +1,32 @@
 package qframe
 
 import (
+	"reflect"
+	"time"
+
+	"github.com/tobgu/qframe/config/interpolate"
+	"github.com/tobgu/qframe/config/rolling"
+	"github.com/tobgu/qframe/decimal"
+	"github.com/tobgu/qframe/filter"
+	"github.com/tobgu/qframe/internal/bcolumn"
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/dcolumn"
+	"github.com/tobgu/qframe/internal/ecolumn"
+	"github.com/tobgu/qframe/internal/f32column"
+	"github.com/tobgu/qframe/internal/fcolumn"
 	"github.com/tobgu/qframe/internal/grouper"
+	"github.com/tobgu/qframe/internal/i16column"
+	"github.com/tobgu/qframe/internal/i32column"
+	"github.com/tobgu/qframe/internal/i8column"
 	"github.com/tobgu/qframe/internal/icolumn"
 	"github.com/tobgu/qframe/internal/index"
+	"github.com/tobgu/qframe/internal/scolumn"
+	qfsort "github.com/tobgu/qframe/internal/sort"
+	qfstrings "github.com/tobgu/qframe/internal/strings"
+	"github.com/tobgu/qframe/internal/tcolumn"
+	"github.com/tobgu/qframe/internal/u16column"
+	"github.com/tobgu/qframe/internal/u32column"
+	"github.com/tobgu/qframe/internal/u8column"
 	"github.com/tobgu/qframe/qerrors"
 	"github.com/tobgu/qframe/types"
 )
@@ -19,13 +42,21 @@ type Grouper struct {
 	groupedColumns []string
 	columns        []namedColumn
 	columnsByName  map[string]namedColumn
+	index          index.Int
+	orders         []Order
 	Err            error
 	Stats          GroupStats
 }
 
 // Aggregation represents a function to apply to a column.
 type Aggregation struct {
-	// Fn is the aggregation function to apply.
+	// Fn is the aggregation function to apply. It may also be the name of a built in aggregation function,
+	// see Doc() for the built in aggregations available for each column type. Some built in aggregations
+	// over int columns, eg. avg, median and std, result in float columns. Quantile aggregations are named
+	// by the quantile, eg. "quantile(0.99)".
+	//
+	// Null values in int and bool columns are not passed to Fn. Groups with only null values are null
+	// in the result if the result type of Fn is the same as the column type.
 	//
 	// IMPORTANT: For pointer and reference types you must not assume that the data passed argument
 	// to this function is valid after the function returns. If you plan to keep it around you need
@@ -34,6 +65,189 @@ type Aggregation struct {
 
 	// Column is the name of the column to apply the aggregation to.
 	Column string
+
+	// Columns are the names of the columns to apply an aggregation over multiple columns to.
+	// In this case Fn must be a function taking one slice per column and returning a single value,
+	// eg. func(price []float64, volume []int) float64 for a volume weighted average price.
+	// Rows with null in any of the int and bool columns are not passed to Fn.
+	// Column must not be set and As must be set when using Columns.
+	Columns []string
+
+	// As is the name of the column that the result is written to. If not set the name of the
+	// aggregated column is used. Setting As allows the same column to be aggregated multiple times.
+	As string
+}
+
+func (a Aggregation) dstCol() string {
+	if a.As != "" {
+		return a.As
+	}
+	return a.Column
+}
+
+// Sort returns a new Grouper where the rows within each group are sorted according to the orders specified.
+// The order of the rows within the groups determines the result of window functions and the order in which
+// values are passed to aggregation functions.
+//
+// Time complexity O(m * n * log(n)) where m = number of columns to sort by, n = number of rows.
+func (g Grouper) Sort(orders ...Order) Grouper {
+	if g.Err != nil {
+		return g
+	}
+
+	comparables := make([]column.Comparable, 0, len(orders))
+	for _, o := range orders {
+		s, ok := g.columnsByName[o.Column]
+		if !ok {
+			return Grouper{Err: qerrors.New("Sort", unknownCol(o.Column))}
+		}
+
+		comparables = append(comparables, s.Comparable(o.Reverse, false, o.NullLast))
+	}
+
+	newG := g
+	newG.orders = orders
+	newG.indices = make([]index.Int, len(g.indices))
+	for i, ix := range g.indices {
+		newIx := ix.Copy()
+		qfsort.New(newIx, comparables).Sort()
+		newG.indices[i] = newIx
+	}
+
+	return newG
+}
+
+func (g Grouper) aggregateColumn(agg Aggregation) (column.Column, error) {
+	col, ok := g.columnsByName[agg.Column]
+	if !ok {
+		return nil, qerrors.New("Aggregate", unknownCol(agg.Column))
+	}
+
+	if agg.Fn == "count" {
+		// Special convenience case for "count" which would normally require a cast from
+		// any other type of column to int before being executed.
+		counts := make([]int, len(g.indices))
+		for i, ix := range g.indices {
+			counts[i] = len(ix)
+		}
+
+		return icolumn.New(counts), nil
+	}
+
+	result, err := col.Aggregate(g.indices, agg.Fn)
+	if err != nil {
+		return nil, qerrors.Propagate("Aggregate", err)
+	}
+
+	return newResultColumn("Aggregate", result)
+}
+
+// groupSlice returns a slice with the data at the positions in ix of col.
+func groupSlice(col column.Column, ix index.Int) interface{} {
+	switch c := col.(type) {
+	case icolumn.Column:
+		return c.View(ix).Slice()
+	case fcolumn.Column:
+		return c.View(ix).Slice()
+	case bcolumn.Column:
+		return c.View(ix).Slice()
+	case scolumn.Column:
+		return c.View(ix).Slice()
+	case ecolumn.Column:
+		return c.View(ix).Slice()
+	case f32column.Column:
+		return c.View(ix).Slice()
+	case i8column.Column:
+		return c.View(ix).Slice()
+	case i16column.Column:
+		return c.View(ix).Slice()
+	case i32column.Column:
+		return c.View(ix).Slice()
+	case u8column.Column:
+		return c.View(ix).Slice()
+	case u16column.Column:
+		return c.View(ix).Slice()
+	case u32column.Column:
+		return c.View(ix).Slice()
+	case tcolumn.Column:
+		// Null values have been removed, see withoutNulls
+		view := c.View(ix)
+		result := make([]time.Time, view.Len())
+		for i := range result {
+			result[i] = view.ItemAt(i)
+		}
+		return result
+	case dcolumn.Column:
+		view := c.View(ix)
+		result := make([]decimal.Decimal, view.Len())
+		for i := range result {
+			result[i] = view.ItemAt(i)
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// withoutNulls returns the positions in ix that are not null in any of the int, bool, time and decimal columns,
+// including the narrow int columns, in cols.
+// Null values in other column types are represented in the data and passed to the aggregation functions.
+func withoutNulls(cols []column.Column, ix index.Int) (index.Int, error) {
+	bIndex := index.NewBool(len(ix))
+	for _, col := range cols {
+		if hasNullSet(col.DataType()) {
+			if err := col.Filter(ix, filter.IsNull, nil, bIndex); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := make(index.Int, 0, len(ix))
+	for i, isNull := range bIndex {
+		if !isNull {
+			result = append(result, ix[i])
+		}
+	}
+
+	return result, nil
+}
+
+// aggregateColumns applies an aggregation over multiple columns. Since the number and types of
+// the columns are only known at runtime the aggregation function is called using reflection.
+func (g Grouper) aggregateColumns(agg Aggregation) (column.Column, error) {
+	fn := reflect.ValueOf(agg.Fn)
+	if fn.Kind() != reflect.Func || fn.Type().NumIn() != len(agg.Columns) || fn.Type().NumOut() != 1 {
+		return nil, qerrors.New("Aggregate", "invalid aggregation function type for %d columns: %v", len(agg.Columns), reflect.TypeOf(agg.Fn))
+	}
+
+	cols := make([]column.Column, len(agg.Columns))
+	for i, name := range agg.Columns {
+		col, ok := g.columnsByName[name]
+		if !ok {
+			return nil, qerrors.New("Aggregate", unknownCol(name))
+		}
+
+		if argType, sliceType := fn.Type().In(i), reflect.TypeOf(groupSlice(col.Column, index.Int{})); argType != sliceType {
+			return nil, qerrors.New("Aggregate", "invalid argument type for column %s, %v != %v", name, argType, sliceType)
+		}
+		cols[i] = col.Column
+	}
+
+	result := reflect.MakeSlice(reflect.SliceOf(fn.Type().Out(0)), 0, len(g.indices))
+	args := make([]reflect.Value, len(cols))
+	for _, ix := range g.indices {
+		ix, err := withoutNulls(cols, ix)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, col := range cols {
+			args[i] = reflect.ValueOf(groupSlice(col, ix))
+		}
+		result = reflect.Append(result, fn.Call(args)[0])
+	}
+
+	return newResultColumn("Aggregate", result.Interface())
 }
 
 // Aggregate applies the given aggregations to all row groups in the Grouper.
@@ -61,39 +275,118 @@ func (g Grouper) Aggregate(aggs ...Aggregation) QFrame {
 		newColumns = append(newColumns, col)
 	}
 
-	var err error
 	for _, agg := range aggs {
-		col, ok := g.columnsByName[agg.Column]
-		if !ok {
-			return QFrame{Err: qerrors.New("Aggregate", unknownCol(agg.Column))}
+		if len(agg.Columns) > 0 {
+			if agg.Column != "" {
+				return QFrame{Err: qerrors.New("Aggregate", "Column and Columns must not both be set")}
+			}
+
+			if agg.As == "" {
+				return QFrame{Err: qerrors.New("Aggregate", "As must be set when aggregating over multiple columns")}
+			}
 		}
 
-		_, ok = newColumnsByName[agg.Column]
-		if ok {
+		dstCol := agg.dstCol()
+		if err := qfstrings.CheckName(dstCol); err != nil {
+			return QFrame{Err: qerrors.Propagate("Aggregate", err)}
+		}
+
+		if _, ok := newColumnsByName[dstCol]; ok {
 			return QFrame{Err: qerrors.New(
 				"Aggregate",
-				"cannot aggregate on column that is part of group by or is already an aggregate: %s", agg.Column)}
+				"cannot aggregate on column that is part of group by or is already an aggregate: %s", dstCol)}
 		}
 
-		if agg.Fn == "count" {
-			// Special convenience case for "count" which would normally require a cast from
-			// any other type of column to int before being executed.
-			counts := make([]int, len(g.indices))
-			for i, ix := range g.indices {
-				counts[i] = len(ix)
-			}
-
-			col.Column = icolumn.New(counts)
+		var col column.Column
+		var err error
+		if len(agg.Columns) > 0 {
+			col, err = g.aggregateColumns(agg)
 		} else {
-			col.Column, err = col.Aggregate(g.indices, agg.Fn)
-			if err != nil {
-				return QFrame{Err: qerrors.Propagate("Aggregate", err)}
-			}
+			col, err = g.aggregateColumn(agg)
 		}
 
-		newColumnsByName[agg.Column] = col
-		newColumns = append(newColumns, col)
+		if err != nil {
+			return QFrame{Err: err}
+		}
+
+		newCol := namedColumn{Column: col, name: dstCol, pos: len(newColumns)}
+		newColumnsByName[dstCol] = newCol
+		newColumns = append(newColumns, newCol)
 	}
 
 	return QFrame{columns: newColumns, columnsByName: newColumnsByName, index: index.NewAscending(uint32(len(g.indices)))}
 }
+
+// Rolling works like QFrame.Rolling except that the rolling windows never extend beyond a group.
+// The rows within each group are processed in the order given by Sort while the resulting QFrame
+// keeps the row order of the QFrame that was grouped.
+//
+// Time complexity O(n * m) where n = number of rows, m = window size.
+func (g Grouper) Rolling(fn types.SliceFuncOrBuiltInId, dstCol, srcCol string, configFns ...rolling.ConfigFunc) QFrame {
+	if g.Err != nil {
+		return QFrame{Err: g.Err}
+	}
+
+	qf := QFrame{columns: g.columns, columnsByName: g.columnsByName, index: g.index}
+	conf, err := rolling.NewConfig(configFns)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	resultColumn, err := rollingColumn(fn, srcCol, g.columnsByName, g.indices, conf)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	return qf.setColumn(dstCol, resultColumn)
+}
+
+// Interpolate works like QFrame.Interpolate except that only values within the same group are used.
+// The rows within each group are processed in the order given by Sort while the resulting QFrame
+// keeps the row order of the QFrame that was grouped.
+//
+// Time complexity O(n) where n = number of rows.
+func (g Grouper) Interpolate(dstCol, srcCol string, configFns ...interpolate.ConfigFunc) QFrame {
+	if g.Err != nil {
+		return QFrame{Err: g.Err}
+	}
+
+	qf := QFrame{columns: g.columns, columnsByName: g.columnsByName, index: g.index}
+	conf, err := interpolate.NewConfig(configFns)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	resultColumn, err := interpolateColumn(srcCol, g.columnsByName, g.indices, conf)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	return qf.setColumn(dstCol, resultColumn)
+}
+
+// FFill works like QFrame.FFill except that values are only filled from rows in the same group.
+// The rows within each group are processed in the order given by Sort while the resulting QFrame
+// keeps the row order of the QFrame that was grouped.
+//
+// Time complexity O(m * n) where m = number of columns, n = number of rows.
+func (g Grouper) FFill(cols ...string) QFrame {
+	if g.Err != nil {
+		return QFrame{Err: g.Err}
+	}
+
+	qf := QFrame{columns: g.columns, columnsByName: g.columnsByName, index: g.index}
+	return qf.fill("FFill", cols, g.indices, false)
+}
+
+// BFill works like QFrame.BFill except that values are only filled from rows in the same group.
+//
+// Time complexity O(m * n) where m = number of columns, n = number of rows.
+func (g Grouper) BFill(cols ...string) QFrame {
+	if g.Err != nil {
+		return QFrame{Err: g.Err}
+	}
+
+	qf := QFrame{columns: g.columns, columnsByName: g.columnsByName, index: g.index}
+	return qf.fill("BFill", cols, g.indices, true)
+}
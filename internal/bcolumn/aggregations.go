@@ -1,9 +1,11 @@
 package bcolumn
 
-var aggregations = map[string]func([]bool) bool{
+var aggregations = map[string]interface{}{
 	"majority": majority,
 }
 
+var quantileAggregations = map[string]func(q float64) interface{}{}
+
 func majority(b []bool) bool {
 	tCount, fCount := 0, 0
 	for _, x := range b {
@@ -6,11 +6,27 @@ import (
 	"github.com/tobgu/qframe/internal/index"
 	"github.com/tobgu/qframe/qerrors"
 	"github.com/tobgu/qframe/types"
+	"math/rand"
 	"reflect"
 	"strconv"
 )
 
 func (c Comparable) Compare(i, j uint32) column.CompareResult {
+	if c.nulls != nil {
+		xNull, yNull := c.nulls.Contains(i), c.nulls.Contains(j)
+		if xNull || yNull {
+			if !xNull {
+				return c.nullGtValue
+			}
+
+			if !yNull {
+				return c.nullLtValue
+			}
+
+			return c.equalNullValue
+		}
+	}
+
 	x, y := c.data[i], c.data[j]
 	if x == y {
 		return column.Equal
@@ -24,6 +40,17 @@ func (c Comparable) Compare(i, j uint32) column.CompareResult {
 }
 
 func (c Comparable) Hash(i uint32, seed uint64) uint64 {
+	if c.nulls.Contains(i) {
+		if c.equalNullValue == column.NotEqual {
+			// Use a random value here to avoid hash collisions when
+			// we don't consider null to equal null.
+			return rand.Uint64()
+		}
+
+		b := [1]byte{2}
+		return hash.HashBytes(b[:], seed)
+	}
+
 	if c.data[i] {
 		b := [1]byte{1}
 		return hash.HashBytes(b[:], seed)
@@ -37,17 +64,25 @@ func (c Column) DataType() types.DataType {
 	return types.Bool
 }
 
-func (c Column) StringAt(i uint32, _ string) string {
+func (c Column) StringAt(i uint32, naRep string) string {
+	if c.nulls.Contains(i) {
+		return naRep
+	}
+
 	return strconv.FormatBool(c.data[i])
 }
 
 func (c Column) AppendByteStringAt(buf []byte, i uint32) []byte {
+	if c.nulls.Contains(i) {
+		return append(buf, "null"...)
+	}
+
 	return strconv.AppendBool(buf, c.data[i])
 }
 
 func (c Column) ByteSize() int {
-	// Slice header + data
-	return 2*8 + cap(c.data)
+	// Slice header + data + nulls
+	return 2*8 + cap(c.data) + c.nulls.ByteSize()
 }
 
 func (c Column) Equals(index index.Int, other column.Column, otherIndex index.Int) bool {
@@ -57,7 +92,8 @@ func (c Column) Equals(index index.Int, other column.Column, otherIndex index.In
 	}
 
 	for ix, x := range index {
-		if c.data[x] != otherI.data[otherIndex[ix]] {
+		y := otherIndex[ix]
+		if c.data[x] != otherI.data[y] || c.nulls.Contains(x) != otherI.nulls.Contains(y) {
 			return false
 		}
 	}
@@ -67,6 +103,12 @@ func (c Column) Equals(index index.Int, other column.Column, otherIndex index.In
 
 func (c Column) filterBuiltIn(index index.Int, comparator string, comparatee interface{}, bIndex index.Bool) error {
 	switch t := comparatee.(type) {
+	case nil:
+		compFunc, ok := filterFuncs0[comparator]
+		if !ok {
+			return qerrors.New("filter bool", "invalid comparison operator to zero argument filter, %v", comparator)
+		}
+		compFunc(index, c, bIndex)
 	case bool:
 		compFunc, ok := filterFuncs[comparator]
 		if !ok {
@@ -110,8 +152,13 @@ func (c Column) filterCustom2(index index.Int, fn func(bool, bool) bool, compara
 
 func (c Column) Filter(index index.Int, comparator interface{}, comparatee interface{}, bIndex index.Bool) error {
 	var err error
+	nullPositions := c.unmatchedNulls(index, comparatee, bIndex)
 	switch t := comparator.(type) {
 	case string:
+		if comparatee == nil {
+			// Zero argument filters are the null filters, they decide on null values themselves
+			nullPositions = nil
+		}
 		err = c.filterBuiltIn(index, t, comparatee, bIndex)
 	case func(bool) bool:
 		c.filterCustom1(index, t, bIndex)
@@ -120,6 +167,8 @@ func (c Column) Filter(index index.Int, comparator interface{}, comparatee inter
 	default:
 		err = qerrors.New("filter bool", "invalid filter type %v", reflect.TypeOf(comparator))
 	}
+
+	maskNulls(nullPositions, comparator, bIndex)
 	return err
 }
 
@@ -6,6 +6,8 @@ func Doc() string {
 	return "\n Built in filters\n" +
 		"  !=\n" +
 		"  =\n" +
+		"  isnotnull\n" +
+		"  isnull\n" +
 
 		"\n Built in aggregations\n" +
 		"  majority\n" +
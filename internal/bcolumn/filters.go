@@ -5,6 +5,11 @@ import (
 	"github.com/tobgu/qframe/internal/index"
 )
 
+var filterFuncs0 = map[string]func(index.Int, Column, index.Bool){
+	filter.IsNull:    isNull,
+	filter.IsNotNull: isNotNull,
+}
+
 var filterFuncs = map[string]func(index.Int, []bool, bool, index.Bool){
 	filter.Eq:  eq,
 	filter.Neq: neq,
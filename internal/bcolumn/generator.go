@@ -40,6 +40,6 @@ func GenerateFilters() (*bytes.Buffer, error) {
 func GenerateDoc() (*bytes.Buffer, error) {
 	return template.GenerateDocs(
 		"bcolumn",
-		maps.StringKeys(filterFuncs, filterFuncs2),
+		maps.StringKeys(filterFuncs0, filterFuncs, filterFuncs2),
 		maps.StringKeys(aggregations))
 }
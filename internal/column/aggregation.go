@@ -0,0 +1,39 @@
+package column
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/tobgu/qframe/qerrors"
+)
+
+// ParseParametric parses built in aggregation identifiers of the form name(arg), eg. quantile(0.9).
+// ok is false if id is not of that form.
+func ParseParametric(id string) (name, arg string, ok bool) {
+	start := strings.IndexByte(id, '(')
+	if start < 1 || !strings.HasSuffix(id, ")") {
+		return "", "", false
+	}
+
+	return id[:start], id[start+1 : len(id)-1], true
+}
+
+// ParseQuantile parses quantile aggregation identifiers of the form name(q), eg. quantile(0.9).
+// ok is false if id is not of that form, err is set if q is not a number in the range [0, 1].
+func ParseQuantile(id string) (name string, q float64, ok bool, err error) {
+	name, arg, ok := ParseParametric(id)
+	if !ok {
+		return "", 0, false, nil
+	}
+
+	q, err = strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return name, 0, true, qerrors.New("ParseQuantile", "invalid quantile in %s", id)
+	}
+
+	if q < 0 || q > 1 {
+		return name, q, true, qerrors.New("ParseQuantile", "quantile must be in the range [0, 1], was %v", q)
+	}
+
+	return name, q, true, nil
+}
@@ -2,9 +2,9 @@ package column
 
 import (
 	"fmt"
-	"github.com/tobgu/qframe/config/rolling"
 
 	"github.com/tobgu/qframe/internal/index"
+	qfrolling "github.com/tobgu/qframe/internal/rolling"
 	"github.com/tobgu/qframe/types"
 )
 
@@ -12,9 +12,11 @@ type Column interface {
 	fmt.Stringer
 	Filter(index index.Int, comparator interface{}, comparatee interface{}, bIndex index.Bool) error
 	Subset(index index.Int) Column
+	Append(cols ...Column) (Column, error)
 	Equals(index index.Int, other Column, otherIndex index.Int) bool
 	Comparable(reverse, equalNull, nullLast bool) Comparable
-	Aggregate(indices []index.Int, fn interface{}) (Column, error)
+	// Aggregate returns a Column, or a slice of the result type if it differs from the column type.
+	Aggregate(indices []index.Int, fn interface{}) (interface{}, error)
 	StringAt(i uint32, naRep string) string
 	AppendByteStringAt(buf []byte, i uint32) []byte
 	ByteSize() int
@@ -23,12 +25,28 @@ type Column interface {
 	Apply1(fn interface{}, ix index.Int) (interface{}, error)
 	Apply2(fn interface{}, s2 Column, ix index.Int) (Column, error)
 
-	Rolling(fn interface{}, ix index.Int, config rolling.Config) (Column, error)
+	Rolling(fn interface{}, ix index.Int, windows []qfrolling.Window, padValue interface{}) (Column, error)
+	IntervalWindows(fn interface{}, ix index.Int, position string) ([]qfrolling.Window, error)
 
 	FunctionType() types.FunctionType
 	DataType() types.DataType
 }
 
+// Widener is implemented by the columns that store their values using a narrower type than
+// the corresponding 64 bit column. Widen returns the values in a column of the 64 bit type.
+type Widener interface {
+	Widen() Column
+}
+
+// Widen returns col as a 64 bit column if it is a narrow column, col itself otherwise.
+func Widen(col Column) Column {
+	if w, ok := col.(Widener); ok {
+		return w.Widen()
+	}
+
+	return col
+}
+
 type CompareResult byte
 
 const (
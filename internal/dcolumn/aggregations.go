@@ -0,0 +1,73 @@
+package dcolumn
+
+import (
+	"math"
+
+	"github.com/tobgu/qframe/decimal"
+	"github.com/tobgu/qframe/qerrors"
+)
+
+// NB! Null values are not part of the slices passed to the built in aggregations.
+// The aggregations returning a decimal are never called with an empty slice.
+
+// unscaledAggregation is a built in aggregation operating directly on the unscaled values
+// of a column. The result has the same scale as the column.
+type unscaledAggregation func(values []int64) (int64, error)
+
+var aggregations = map[string]interface{}{
+	"sum":            unscaledAggregation(sum),
+	"min":            unscaledAggregation(min),
+	"max":            unscaledAggregation(max),
+	"first":          unscaledAggregation(first),
+	"last":           unscaledAggregation(last),
+	"count_distinct": countDistinct,
+}
+
+// sum returns the exact sum of values, an error is returned if it is out of range.
+func sum(values []int64) (int64, error) {
+	var result int64
+	for _, v := range values {
+		if (v > 0 && result > math.MaxInt64-v) || (v < 0 && result < math.MinInt64-v) {
+			return 0, qerrors.New("sum", "decimal sum out of range")
+		}
+		result += v
+	}
+	return result, nil
+}
+
+func min(values []int64) (int64, error) {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result, nil
+}
+
+func max(values []int64) (int64, error) {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v > result {
+			result = v
+		}
+	}
+	return result, nil
+}
+
+func first(values []int64) (int64, error) {
+	return values[0], nil
+}
+
+func last(values []int64) (int64, error) {
+	return values[len(values)-1], nil
+}
+
+// countDistinct returns the number of distinct values, nulls are not counted.
+func countDistinct(values []decimal.Decimal) int {
+	seen := make(map[int64]struct{}, len(values))
+	for _, v := range values {
+		seen[v.Unscaled()] = struct{}{}
+	}
+	return len(seen)
+}
@@ -0,0 +1,766 @@
+package dcolumn
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"unsafe"
+
+	"github.com/tobgu/qframe/decimal"
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/hash"
+	"github.com/tobgu/qframe/internal/index"
+	"github.com/tobgu/qframe/internal/nulls"
+	qfrolling "github.com/tobgu/qframe/internal/rolling"
+	"github.com/tobgu/qframe/qerrors"
+	"github.com/tobgu/qframe/types"
+)
+
+// Column holds decimal values as unscaled integers. All values share the scale of the column.
+type Column struct {
+	data []int64
+
+	// nulls holds the positions of null values, the data at these positions is always zero.
+	nulls nulls.Set
+
+	scale int
+}
+
+// maxScale returns the largest scale of values.
+func maxScale(values []decimal.Decimal) int {
+	scale := 0
+	for _, v := range values {
+		if v.Scale() > scale {
+			scale = v.Scale()
+		}
+	}
+	return scale
+}
+
+// New creates a new column from values. The scale of the column is the largest scale of
+// the values, an error is returned if any value cannot be represented using that scale.
+func New(values []decimal.Decimal) (Column, error) {
+	return NewNullable(values, nil)
+}
+
+// NewNullable creates a new column from values where the positions in nullSet are null.
+// nullSet may be nil if there are no nulls. The values at null positions are ignored.
+func NewNullable(values []decimal.Decimal, nullSet nulls.Set) (Column, error) {
+	scale := maxScale(values)
+	data := make([]int64, len(values))
+	for i, v := range values {
+		if nullSet.Contains(uint32(i)) {
+			continue
+		}
+
+		r, err := v.Rescale(scale)
+		if err != nil {
+			return Column{}, qerrors.Propagate("dcolumn.New", err)
+		}
+		data[i] = r.Unscaled()
+	}
+
+	return Column{data: data, nulls: nullSet, scale: scale}, nil
+}
+
+// NewPtrs creates a new column from pointers to values, nil pointers are null.
+func NewPtrs(values []*decimal.Decimal) (Column, error) {
+	data := make([]decimal.Decimal, len(values))
+	var nullSet nulls.Set
+	for i, v := range values {
+		if v == nil {
+			if nullSet == nil {
+				nullSet = nulls.New(len(values))
+			}
+			nullSet.Add(uint32(i))
+			continue
+		}
+		data[i] = *v
+	}
+
+	return NewNullable(data, nullSet)
+}
+
+// Parse creates a new column from the textual representation of decimals, nil is null.
+func Parse(values []*string) (Column, error) {
+	data := make([]decimal.Decimal, len(values))
+	var nullSet nulls.Set
+	for i, s := range values {
+		if s == nil {
+			if nullSet == nil {
+				nullSet = nulls.New(len(values))
+			}
+			nullSet.Add(uint32(i))
+			continue
+		}
+
+		d, err := decimal.Parse(*s)
+		if err != nil {
+			return Column{}, qerrors.Propagate("dcolumn.Parse", err)
+		}
+		data[i] = d
+	}
+
+	return NewNullable(data, nullSet)
+}
+
+// NewNull creates a new column with count null values.
+func NewNull(count int) Column {
+	nullSet := nulls.New(count)
+	for i := 0; i < count; i++ {
+		nullSet.Add(uint32(i))
+	}
+
+	return Column{data: make([]int64, count), nulls: nullSet}
+}
+
+func (c Column) decimalAt(i uint32) decimal.Decimal {
+	return decimal.New(c.data[i], c.scale)
+}
+
+// rescale returns the column using the given scale, which must not be smaller than the current scale.
+func (c Column) rescale(scale int) (Column, error) {
+	if scale == c.scale {
+		return c, nil
+	}
+
+	data := make([]int64, len(c.data))
+	for i, x := range c.data {
+		r, err := decimal.New(x, c.scale).Rescale(scale)
+		if err != nil {
+			return Column{}, qerrors.Propagate(c.fnName("rescale"), err)
+		}
+		data[i] = r.Unscaled()
+	}
+
+	return Column{data: data, nulls: c.nulls, scale: scale}, nil
+}
+
+// sameScale returns c1 and c2 rescaled to the larger of their scales.
+func sameScale(c1, c2 Column) (Column, Column, error) {
+	var err error
+	if c1.scale > c2.scale {
+		c2, err = c2.rescale(c1.scale)
+	} else if c2.scale > c1.scale {
+		c1, err = c1.rescale(c2.scale)
+	}
+
+	return c1, c2, err
+}
+
+func (c Column) fnName(name string) string {
+	return fmt.Sprintf("%s.%s", c.DataType(), name)
+}
+
+func (c Column) DataType() types.DataType {
+	return types.Decimal
+}
+
+func (c Column) FunctionType() types.FunctionType {
+	return types.FunctionTypeDecimal
+}
+
+func (c Column) StringAt(i uint32, naRep string) string {
+	if c.nulls.Contains(i) {
+		return naRep
+	}
+
+	return c.decimalAt(i).String()
+}
+
+// AppendByteStringAt appends the value at i as a JSON number, keeping all decimals of the column.
+func (c Column) AppendByteStringAt(buf []byte, i uint32) []byte {
+	if c.nulls.Contains(i) {
+		return append(buf, "null"...)
+	}
+
+	return c.decimalAt(i).AppendBytes(buf)
+}
+
+func (c Column) ByteSize() int {
+	// Slice header + data + nulls + scale
+	return 2*8 + 8*cap(c.data) + c.nulls.ByteSize() + 8
+}
+
+func (c Column) Len() int {
+	return len(c.data)
+}
+
+func (c Column) String() string {
+	strs := make([]string, len(c.data))
+	for i := range c.data {
+		strs[i] = c.StringAt(uint32(i), "null")
+	}
+	return fmt.Sprintf("%v", strs)
+}
+
+// Equals compares the values and null status of the columns. Values are equal if they represent
+// the same number, the scales of the columns are not considered.
+func (c Column) Equals(index index.Int, other column.Column, otherIndex index.Int) bool {
+	otherD, ok := other.(Column)
+	if !ok {
+		return false
+	}
+
+	for ix, x := range index {
+		y := otherIndex[ix]
+		xNull, yNull := c.nulls.Contains(x), otherD.nulls.Contains(y)
+		if xNull != yNull {
+			return false
+		}
+
+		if !xNull && !c.decimalAt(x).Equal(otherD.decimalAt(y)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c Column) subset(index index.Int) Column {
+	data := make([]int64, len(index))
+	for i, ix := range index {
+		data[i] = c.data[ix]
+	}
+
+	return Column{data: data, nulls: c.nulls.Subset(index), scale: c.scale}
+}
+
+func (c Column) Subset(index index.Int) column.Column {
+	return c.subset(index)
+}
+
+// Append returns a new column holding the data of this column followed by the data of all columns
+// in cols. All columns must be decimal columns. The resulting column has the largest scale of the
+// columns, an error is returned if any value cannot be represented using that scale.
+func (c Column) Append(cols ...column.Column) (column.Column, error) {
+	size, scale := len(c.data), c.scale
+	for _, col := range cols {
+		dCol, ok := col.(Column)
+		if !ok {
+			return nil, qerrors.New(c.fnName("Append"), "invalid column type: %s", col.DataType())
+		}
+
+		size += dCol.Len()
+		if dCol.scale > scale {
+			scale = dCol.scale
+		}
+	}
+
+	data := make([]int64, 0, size)
+	nullSets, lengths := make([]nulls.Set, 0, len(cols)+1), make([]int, 0, len(cols)+1)
+	for _, col := range append([]column.Column{c}, cols...) {
+		dCol, err := col.(Column).rescale(scale)
+		if err != nil {
+			return nil, qerrors.Propagate(c.fnName("Append"), err)
+		}
+
+		data = append(data, dCol.data...)
+		nullSets, lengths = append(nullSets, dCol.nulls), append(lengths, dCol.Len())
+	}
+
+	return Column{data: data, nulls: nulls.Concat(nullSets, lengths), scale: scale}, nil
+}
+
+func (c Column) Comparable(reverse, equalNull, nullLast bool) column.Comparable {
+	result := Comparable{data: c.data, nulls: c.nulls, ltValue: column.LessThan, gtValue: column.GreaterThan, nullLtValue: column.LessThan, nullGtValue: column.GreaterThan, equalNullValue: column.NotEqual}
+	if reverse {
+		result.ltValue, result.nullLtValue, result.gtValue, result.nullGtValue =
+			result.gtValue, result.nullGtValue, result.ltValue, result.nullLtValue
+	}
+
+	if nullLast {
+		result.nullLtValue, result.nullGtValue = result.nullGtValue, result.nullLtValue
+	}
+
+	if equalNull {
+		result.equalNullValue = column.Equal
+	}
+
+	return result
+}
+
+func (c Comparable) Compare(i, j uint32) column.CompareResult {
+	if c.nulls != nil {
+		xNull, yNull := c.nulls.Contains(i), c.nulls.Contains(j)
+		if xNull || yNull {
+			if !xNull {
+				return c.nullGtValue
+			}
+
+			if !yNull {
+				return c.nullLtValue
+			}
+
+			return c.equalNullValue
+		}
+	}
+
+	x, y := c.data[i], c.data[j]
+	if x < y {
+		return c.ltValue
+	}
+
+	if x > y {
+		return c.gtValue
+	}
+
+	return column.Equal
+}
+
+func (c Comparable) Hash(i uint32, seed uint64) uint64 {
+	if c.nulls.Contains(i) {
+		if c.equalNullValue == column.NotEqual {
+			// Use a random value here to avoid hash collisions when
+			// we don't consider null to equal null.
+			return rand.Uint64()
+		}
+
+		b := [1]byte{0}
+		return hash.HashBytes(b[:], seed)
+	}
+
+	x := &c.data[i]
+	b := (*[8]byte)(unsafe.Pointer(x))[:]
+	return hash.HashBytes(b, seed)
+}
+
+// decimalComp converts comparatee to a decimal if it is a decimal, an int or a string.
+func decimalComp(comparatee interface{}) (decimal.Decimal, bool, error) {
+	switch t := comparatee.(type) {
+	case decimal.Decimal:
+		return t, true, nil
+	case int:
+		return decimal.FromInt(t), true, nil
+	case string:
+		d, err := decimal.Parse(t)
+		if err != nil {
+			return decimal.Decimal{}, false, qerrors.Propagate("filter decimal", err)
+		}
+		return d, true, nil
+	default:
+		return decimal.Decimal{}, false, nil
+	}
+}
+
+func (c Column) newDecimalSet(input interface{}) (decimalSet, bool) {
+	var values []interface{}
+	switch t := input.(type) {
+	case []decimal.Decimal:
+		for _, v := range t {
+			values = append(values, v)
+		}
+	case []string:
+		for _, v := range t {
+			values = append(values, v)
+		}
+	case []int:
+		for _, v := range t {
+			values = append(values, v)
+		}
+	case []interface{}:
+		values = t
+	default:
+		return nil, false
+	}
+
+	result := make(decimalSet, len(values))
+	for _, v := range values {
+		d, ok, err := decimalComp(v)
+		if !ok || err != nil {
+			return nil, false
+		}
+
+		// Values that cannot be represented using the scale of the column never match
+		if r, err := d.Rescale(c.scale); err == nil {
+			result[r.Unscaled()] = struct{}{}
+		}
+	}
+	return result, true
+}
+
+type decimalSet map[int64]struct{}
+
+func (ds decimalSet) Contains(x int64) bool {
+	_, ok := ds[x]
+	return ok
+}
+
+func (c Column) filterBuiltIn(index index.Int, comparator string, comparatee interface{}, bIndex index.Bool) error {
+	comp, ok, err := decimalComp(comparatee)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		filterFns := filterFuncs1
+		floor, exact := comp.Floor(c.scale)
+		if !exact {
+			// The comparatee has more decimals than the column, compare against the closest lower value instead
+			filterFns = inexactFilterFuncs1
+		}
+
+		filterFn, ok := filterFns[comparator]
+		if !ok {
+			return qerrors.New("filter decimal", "unknown filter operator %v for single value argument", comparator)
+		}
+		filterFn(index, c, floor, bIndex)
+	} else if set, ok := c.newDecimalSet(comparatee); ok {
+		filterFn, ok := multiInputFilterFuncs[comparator]
+		if !ok {
+			return qerrors.New("filter decimal", "unknown filter operator %v for multi value argument", comparator)
+		}
+		filterFn(index, c, set, bIndex)
+	} else if columnC, ok := comparatee.(Column); ok {
+		filterFn, ok := filterFuncs2[comparator]
+		if !ok {
+			return qerrors.New("filter decimal", "unknown filter operator %v for column - column comparison", comparator)
+		}
+
+		col, col2, err := sameScale(c, columnC)
+		if err != nil {
+			return qerrors.Propagate("filter decimal", err)
+		}
+		filterFn(index, col, col2, bIndex)
+	} else if comparatee == nil {
+		filterFn, ok := filterFuncs0[comparator]
+		if !ok {
+			return qerrors.New("filter decimal", "unknown filter operator %v for zero argument", comparator)
+		}
+		filterFn(index, c, bIndex)
+	} else {
+		return qerrors.New("filter decimal", "invalid comparison value type %v", reflect.TypeOf(comparatee))
+	}
+
+	return nil
+}
+
+// Null values never match custom filter functions.
+func (c Column) filterCustom1(index index.Int, fn func(decimal.Decimal) bool, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x && !c.nulls.Contains(index[i]) {
+			bIndex[i] = fn(c.decimalAt(index[i]))
+		}
+	}
+}
+
+func (c Column) filterCustom2(index index.Int, fn func(decimal.Decimal, decimal.Decimal) bool, comparatee interface{}, bIndex index.Bool) error {
+	otherC, ok := comparatee.(Column)
+	if !ok {
+		return qerrors.New("filter decimal", "expected comparatee to be decimal column, was %v", reflect.TypeOf(comparatee))
+	}
+
+	for i, x := range bIndex {
+		if !x && !c.nulls.Contains(index[i]) && !otherC.nulls.Contains(index[i]) {
+			bIndex[i] = fn(c.decimalAt(index[i]), otherC.decimalAt(index[i]))
+		}
+	}
+
+	return nil
+}
+
+func (c Column) Filter(index index.Int, comparator interface{}, comparatee interface{}, bIndex index.Bool) error {
+	var err error
+	switch t := comparator.(type) {
+	case string:
+		err = c.filterBuiltIn(index, t, comparatee, bIndex)
+	case func(decimal.Decimal) bool:
+		c.filterCustom1(index, t, bIndex)
+	case func(decimal.Decimal, decimal.Decimal) bool:
+		err = c.filterCustom2(index, t, comparatee, bIndex)
+	default:
+		err = qerrors.New("filter decimal", "invalid filter type %v", reflect.TypeOf(comparator))
+	}
+	return err
+}
+
+// Apply single argument function. The result may be a column of a different type than the
+// current column. Null values are passed to fn as zero.
+func (c Column) Apply1(fn interface{}, ix index.Int) (interface{}, error) {
+	switch t := fn.(type) {
+	case func(decimal.Decimal) int:
+		result := make([]int, len(c.data))
+		for _, i := range ix {
+			result[i] = t(c.decimalAt(i))
+		}
+		return result, nil
+	case func(decimal.Decimal) float64:
+		result := make([]float64, len(c.data))
+		for _, i := range ix {
+			result[i] = t(c.decimalAt(i))
+		}
+		return result, nil
+	case func(decimal.Decimal) bool:
+		result := make([]bool, len(c.data))
+		for _, i := range ix {
+			result[i] = t(c.decimalAt(i))
+		}
+		return result, nil
+	case func(decimal.Decimal) *string:
+		result := make([]*string, len(c.data))
+		for _, i := range ix {
+			result[i] = t(c.decimalAt(i))
+		}
+		return result, nil
+	case func(decimal.Decimal) decimal.Decimal:
+		result := make([]decimal.Decimal, len(c.data))
+		for _, i := range ix {
+			result[i] = t(c.decimalAt(i))
+		}
+		return New(result)
+	default:
+		return nil, qerrors.New(c.fnName("Apply1"), "cannot apply type %#v to column", fn)
+	}
+}
+
+// Apply double argument function to two columns. Both columns must be decimal columns.
+func (c Column) Apply2(fn interface{}, s2 column.Column, ix index.Int) (column.Column, error) {
+	ss2, ok := s2.(Column)
+	if !ok {
+		return nil, qerrors.New(c.fnName("Apply2"), "invalid column type: %s", s2.DataType())
+	}
+
+	t, ok := fn.(func(decimal.Decimal, decimal.Decimal) decimal.Decimal)
+	if !ok {
+		return nil, qerrors.New(c.fnName("Apply2"), "invalid function type: %#v", fn)
+	}
+
+	result := make([]decimal.Decimal, len(c.data))
+	for _, i := range ix {
+		result[i] = t(c.decimalAt(i), ss2.decimalAt(i))
+	}
+
+	return New(result)
+}
+
+// unscaledWithBuf returns the non null unscaled values at the positions in index, using buf for storage.
+func (c Column) unscaledWithBuf(index index.Int, buf *[]int64) []int64 {
+	if cap(*buf) < len(index) {
+		*buf = make([]int64, 0, len(index))
+	}
+
+	result := (*buf)[:0]
+	for _, ix := range index {
+		if !c.nulls.Contains(ix) {
+			result = append(result, c.data[ix])
+		}
+	}
+
+	*buf = result
+	return result
+}
+
+// decimalsWithBuf returns the non null values at the positions in index, using buf for storage.
+func (c Column) decimalsWithBuf(index index.Int, buf *[]decimal.Decimal) []decimal.Decimal {
+	if cap(*buf) < len(index) {
+		*buf = make([]decimal.Decimal, 0, len(index))
+	}
+
+	result := (*buf)[:0]
+	for _, ix := range index {
+		if !c.nulls.Contains(ix) {
+			result = append(result, c.decimalAt(ix))
+		}
+	}
+
+	*buf = result
+	return result
+}
+
+// aggregate applies fn to the non null values of each group in indices, groups containing only nulls result in null.
+func (c Column) aggregate(indices []index.Int, fn func([]decimal.Decimal) decimal.Decimal) (Column, error) {
+	var resultNulls nulls.Set
+	data := make([]decimal.Decimal, 0, len(indices))
+	var buf []decimal.Decimal
+	for i, ix := range indices {
+		values := c.decimalsWithBuf(ix, &buf)
+		if len(values) == 0 {
+			// Only nulls in group
+			if resultNulls == nil {
+				resultNulls = nulls.New(len(indices))
+			}
+			resultNulls.Add(uint32(i))
+			data = append(data, decimal.Decimal{})
+			continue
+		}
+
+		data = append(data, fn(values))
+	}
+
+	return NewNullable(data, resultNulls)
+}
+
+// aggregateUnscaled applies the built in fn to the non null values of each group in indices. The result
+// has the same scale as the column.
+func (c Column) aggregateUnscaled(indices []index.Int, fn unscaledAggregation) (Column, error) {
+	var resultNulls nulls.Set
+	data := make([]int64, 0, len(indices))
+	var buf []int64
+	for i, ix := range indices {
+		values := c.unscaledWithBuf(ix, &buf)
+		if len(values) == 0 {
+			// Only nulls in group
+			if resultNulls == nil {
+				resultNulls = nulls.New(len(indices))
+			}
+			resultNulls.Add(uint32(i))
+			data = append(data, 0)
+			continue
+		}
+
+		result, err := fn(values)
+		if err != nil {
+			return Column{}, qerrors.Propagate(c.fnName("Aggregate"), err)
+		}
+		data = append(data, result)
+	}
+
+	return Column{data: data, nulls: resultNulls, scale: c.scale}, nil
+}
+
+// Aggregate applies fn to the non null values of each group in indices. The result is either a Column or,
+// if the result type of fn is not decimal, a slice of the result type.
+func (c Column) Aggregate(indices []index.Int, fn interface{}) (interface{}, error) {
+	if name, ok := fn.(string); ok {
+		var ok bool
+		if fn, ok = aggregations[name]; !ok {
+			return nil, qerrors.New(c.fnName("Aggregate"), "aggregation function %s is not defined for column", name)
+		}
+	}
+
+	var buf []decimal.Decimal
+	switch t := fn.(type) {
+	case unscaledAggregation:
+		return c.aggregateUnscaled(indices, t)
+	case func([]decimal.Decimal) decimal.Decimal:
+		return c.aggregate(indices, t)
+	case func([]decimal.Decimal) int:
+		data := make([]int, 0, len(indices))
+		for _, ix := range indices {
+			data = append(data, t(c.decimalsWithBuf(ix, &buf)))
+		}
+		return data, nil
+	case func([]decimal.Decimal) float64:
+		data := make([]float64, 0, len(indices))
+		for _, ix := range indices {
+			data = append(data, t(c.decimalsWithBuf(ix, &buf)))
+		}
+		return data, nil
+	case func([]decimal.Decimal) bool:
+		data := make([]bool, 0, len(indices))
+		for _, ix := range indices {
+			data = append(data, t(c.decimalsWithBuf(ix, &buf)))
+		}
+		return data, nil
+	case func([]decimal.Decimal) *string:
+		data := make([]*string, 0, len(indices))
+		for _, ix := range indices {
+			data = append(data, t(c.decimalsWithBuf(ix, &buf)))
+		}
+		return data, nil
+	default:
+		return nil, qerrors.New(c.fnName("Aggregate"), "invalid aggregation function type: %v", t)
+	}
+}
+
+// Rolling applies fn to the non null values of each window. The result for window i is written to position ix[i],
+// or padValue if the window is incomplete and padValue has been set.
+func (c Column) Rolling(fn interface{}, ix index.Int, windows []qfrolling.Window, padValue interface{}) (column.Column, error) {
+	if name, ok := fn.(string); ok {
+		var ok bool
+		if fn, ok = aggregations[name]; !ok {
+			return nil, qerrors.New(c.fnName("Rolling"), "aggregation function %s is not defined for column", name)
+		}
+
+		if _, ok := fn.(unscaledAggregation); !ok {
+			return nil, qerrors.New(c.fnName("Rolling"), "aggregation function %s has a different result type than the column", name)
+		}
+	}
+
+	var actualFn func([]decimal.Decimal) (decimal.Decimal, error)
+	switch t := fn.(type) {
+	case unscaledAggregation:
+		actualFn = func(values []decimal.Decimal) (decimal.Decimal, error) {
+			unscaled := make([]int64, len(values))
+			for i, v := range values {
+				unscaled[i] = v.Unscaled()
+			}
+			result, err := t(unscaled)
+			return decimal.New(result, c.scale), err
+		}
+	case func([]decimal.Decimal) decimal.Decimal:
+		actualFn = func(values []decimal.Decimal) (decimal.Decimal, error) {
+			return t(values), nil
+		}
+	default:
+		return nil, qerrors.New(c.fnName("Rolling"), "invalid rolling function type: %v", fn)
+	}
+
+	var pad decimal.Decimal
+	if padValue != nil {
+		var ok bool
+		var err error
+		if pad, ok, err = decimalComp(padValue); !ok || err != nil {
+			return nil, qerrors.New(c.fnName("Rolling"), "invalid pad value: %v", padValue)
+		}
+	}
+
+	data := make([]decimal.Decimal, len(c.data))
+	var resultNulls nulls.Set
+	var buf []decimal.Decimal
+	for i, w := range windows {
+		if !w.Complete && padValue != nil {
+			data[ix[i]] = pad
+			continue
+		}
+
+		values := c.decimalsWithBuf(ix[w.Start:w.End], &buf)
+		if len(values) == 0 {
+			// Only nulls in window
+			if resultNulls == nil {
+				resultNulls = nulls.New(len(c.data))
+			}
+			resultNulls.Add(ix[i])
+			continue
+		}
+
+		result, err := actualFn(values)
+		if err != nil {
+			return nil, qerrors.Propagate(c.fnName("Rolling"), err)
+		}
+		data[ix[i]] = result
+	}
+
+	result, err := NewNullable(data, resultNulls)
+	if err != nil {
+		return nil, qerrors.Propagate(c.fnName("Rolling"), err)
+	}
+
+	return result, nil
+}
+
+// IntervalWindows returns the windows given by the interval function fn for the positions in ix.
+func (c Column) IntervalWindows(fn interface{}, ix index.Int, position string) ([]qfrolling.Window, error) {
+	t, ok := fn.(func(decimal.Decimal, decimal.Decimal) bool)
+	if !ok {
+		return nil, qerrors.New(c.fnName("IntervalWindows"), "invalid interval function type: %v", fn)
+	}
+
+	return qfrolling.IntervalWindows(len(ix), position, func(i, j int) bool {
+		return t(c.decimalAt(ix[i]), c.decimalAt(ix[j]))
+	}), nil
+}
+
+func (c Column) View(ix index.Int) View {
+	return View{column: c, index: ix}
+}
+
+type Comparable struct {
+	data           []int64
+	nulls          nulls.Set
+	ltValue        column.CompareResult
+	nullLtValue    column.CompareResult
+	gtValue        column.CompareResult
+	nullGtValue    column.CompareResult
+	equalNullValue column.CompareResult
+}
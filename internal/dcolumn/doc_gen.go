@@ -0,0 +1,25 @@
+package dcolumn
+
+// Code generated from template/... DO NOT EDIT
+
+func Doc() string {
+	return "\n Built in filters\n" +
+		"  !=\n" +
+		"  <\n" +
+		"  <=\n" +
+		"  =\n" +
+		"  >\n" +
+		"  >=\n" +
+		"  in\n" +
+		"  isnotnull\n" +
+		"  isnull\n" +
+
+		"\n Built in aggregations\n" +
+		"  count_distinct\n" +
+		"  first\n" +
+		"  last\n" +
+		"  max\n" +
+		"  min\n" +
+		"  sum\n" +
+		"\n"
+}
@@ -0,0 +1,94 @@
+package dcolumn
+
+import (
+	"github.com/tobgu/qframe/filter"
+	"github.com/tobgu/qframe/internal/index"
+)
+
+var filterFuncs0 = map[string]func(index.Int, Column, index.Bool){
+	filter.IsNull:    isNull,
+	filter.IsNotNull: isNotNull,
+}
+
+var filterFuncs1 = map[string]func(index.Int, Column, int64, index.Bool){
+	filter.Gt:  gt,
+	filter.Gte: gte,
+	filter.Lt:  lt,
+	filter.Lte: lte,
+	filter.Eq:  eq,
+	filter.Neq: neq,
+}
+
+// inexactFilterFuncs1 are used when the comparatee cannot be represented using the scale of
+// the column. They are called with the largest value below the comparatee.
+var inexactFilterFuncs1 = map[string]func(index.Int, Column, int64, index.Bool){
+	filter.Gt:  gt,
+	filter.Gte: gt,
+	filter.Lt:  lte,
+	filter.Lte: lte,
+	filter.Eq:  none,
+	filter.Neq: all,
+}
+
+var multiInputFilterFuncs = map[string]func(index.Int, Column, decimalSet, index.Bool){
+	filter.In: in,
+}
+
+var filterFuncs2 = map[string]func(index.Int, Column, Column, index.Bool){
+	filter.Gt:  gt2,
+	filter.Gte: gte2,
+	filter.Lt:  lt2,
+	filter.Lte: lte2,
+	filter.Eq:  eq2,
+	filter.Neq: neq2,
+}
+
+func neq(index index.Int, c Column, comp int64, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			bIndex[i] = c.nulls.Contains(index[i]) || c.data[index[i]] != comp
+		}
+	}
+}
+
+func none(_ index.Int, _ Column, _ int64, _ index.Bool) {
+}
+
+func all(_ index.Int, _ Column, _ int64, bIndex index.Bool) {
+	for i := range bIndex {
+		bIndex[i] = true
+	}
+}
+
+func in(index index.Int, c Column, comp decimalSet, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			bIndex[i] = !c.nulls.Contains(index[i]) && comp.Contains(c.data[index[i]])
+		}
+	}
+}
+
+func neq2(index index.Int, col, col2 Column, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			pos := index[i]
+			bIndex[i] = col.nulls.Contains(pos) || col2.nulls.Contains(pos) || col.data[pos] != col2.data[pos]
+		}
+	}
+}
+
+func isNull(index index.Int, c Column, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			bIndex[i] = c.nulls.Contains(index[i])
+		}
+	}
+}
+
+func isNotNull(index index.Int, c Column, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			bIndex[i] = !c.nulls.Contains(index[i])
+		}
+	}
+}
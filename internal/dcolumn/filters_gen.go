@@ -0,0 +1,92 @@
+package dcolumn
+
+import (
+	"github.com/tobgu/qframe/internal/index"
+)
+
+// Code generated from template/... DO NOT EDIT
+
+func lt(index index.Int, c Column, comp int64, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			bIndex[i] = !c.nulls.Contains(index[i]) && c.data[index[i]] < comp
+		}
+	}
+}
+
+func lte(index index.Int, c Column, comp int64, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			bIndex[i] = !c.nulls.Contains(index[i]) && c.data[index[i]] <= comp
+		}
+	}
+}
+
+func gt(index index.Int, c Column, comp int64, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			bIndex[i] = !c.nulls.Contains(index[i]) && c.data[index[i]] > comp
+		}
+	}
+}
+
+func gte(index index.Int, c Column, comp int64, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			bIndex[i] = !c.nulls.Contains(index[i]) && c.data[index[i]] >= comp
+		}
+	}
+}
+
+func eq(index index.Int, c Column, comp int64, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			bIndex[i] = !c.nulls.Contains(index[i]) && c.data[index[i]] == comp
+		}
+	}
+}
+
+func lt2(index index.Int, col, col2 Column, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			pos := index[i]
+			bIndex[i] = !col.nulls.Contains(pos) && !col2.nulls.Contains(pos) && col.data[pos] < col2.data[pos]
+		}
+	}
+}
+
+func lte2(index index.Int, col, col2 Column, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			pos := index[i]
+			bIndex[i] = !col.nulls.Contains(pos) && !col2.nulls.Contains(pos) && col.data[pos] <= col2.data[pos]
+		}
+	}
+}
+
+func gt2(index index.Int, col, col2 Column, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			pos := index[i]
+			bIndex[i] = !col.nulls.Contains(pos) && !col2.nulls.Contains(pos) && col.data[pos] > col2.data[pos]
+		}
+	}
+}
+
+func gte2(index index.Int, col, col2 Column, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			pos := index[i]
+			bIndex[i] = !col.nulls.Contains(pos) && !col2.nulls.Contains(pos) && col.data[pos] >= col2.data[pos]
+		}
+	}
+}
+
+func eq2(index index.Int, col, col2 Column, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			pos := index[i]
+			bIndex[i] = !col.nulls.Contains(pos) && !col2.nulls.Contains(pos) && col.data[pos] == col2.data[pos]
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package dcolumn
+
+import (
+	"bytes"
+
+	"github.com/tobgu/qframe/filter"
+	"github.com/tobgu/qframe/internal/maps"
+	"github.com/tobgu/qframe/internal/template"
+)
+
+//go:generate qfgenerate -source=dfilter -dst-file=filters_gen.go
+//go:generate qfgenerate -source=ddoc -dst-file=doc_gen.go
+
+const basicColConstComparison = `
+func {{.name}}(index index.Int, c Column, comp int64, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			bIndex[i] = !c.nulls.Contains(index[i]) && c.data[index[i]] {{.operator}} comp
+		}
+	}
+}
+`
+
+const basicColColComparison = `
+func {{.name}}(index index.Int, col, col2 Column, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x {
+			pos := index[i]
+			bIndex[i] = !col.nulls.Contains(pos) && !col2.nulls.Contains(pos) && col.data[pos] {{.operator}} col2.data[pos]
+		}
+	}
+}
+`
+
+func spec(name, operator, templateStr string) template.Spec {
+	return template.Spec{
+		Name:     name,
+		Template: templateStr,
+		Values:   map[string]interface{}{"name": name, "operator": operator}}
+}
+
+func colConstComparison(name, operator string) template.Spec {
+	return spec(name, operator, basicColConstComparison)
+}
+
+func colColComparison(name, operator string) template.Spec {
+	return spec(name, operator, basicColColComparison)
+}
+
+func GenerateFilters() (*bytes.Buffer, error) {
+	// If adding more filters here make sure to also add a reference to them
+	// in the corresponding filter map so that they can be looked up.
+	return template.GenerateFilters("dcolumn", []template.Spec{
+		colConstComparison("lt", filter.Lt),
+		colConstComparison("lte", filter.Lte),
+		colConstComparison("gt", filter.Gt),
+		colConstComparison("gte", filter.Gte),
+		colConstComparison("eq", "=="), // Go eq ("==") differs from qframe eq ("=")
+		colColComparison("lt2", filter.Lt),
+		colColComparison("lte2", filter.Lte),
+		colColComparison("gt2", filter.Gt),
+		colColComparison("gte2", filter.Gte),
+		colColComparison("eq2", "=="), // Go eq ("==") differs from qframe eq ("=")
+	})
+}
+
+func GenerateDoc() (*bytes.Buffer, error) {
+	return template.GenerateDocs(
+		"dcolumn",
+		maps.StringKeys(filterFuncs0, filterFuncs1, filterFuncs2, multiInputFilterFuncs),
+		maps.StringKeys(aggregations))
+}
@@ -0,0 +1,45 @@
+package dcolumn
+
+import (
+	"github.com/tobgu/qframe/decimal"
+	"github.com/tobgu/qframe/internal/index"
+)
+
+// View is a view into a column that allows access to individual elements by index.
+type View struct {
+	column Column
+	index  index.Int
+}
+
+// ItemAt returns the value at position i with the scale of the column. Null values
+// are returned as zero, use IsNull to tell them apart.
+func (v View) ItemAt(i int) decimal.Decimal {
+	return v.column.decimalAt(v.index[i])
+}
+
+// IsNull returns true if the value at position i is null.
+func (v View) IsNull(i int) bool {
+	return v.column.nulls.Contains(v.index[i])
+}
+
+// Len returns the column length.
+func (v View) Len() int {
+	return len(v.index)
+}
+
+// Slice returns a slice containing a copy of the column data. Null values are nil.
+func (v View) Slice() []*decimal.Decimal {
+	result := make([]*decimal.Decimal, v.Len())
+	for i, j := range v.index {
+		if !v.column.nulls.Contains(j) {
+			d := v.column.decimalAt(j)
+			result[i] = &d
+		}
+	}
+	return result
+}
+
+// Scale returns the number of decimals of the values in the column.
+func (v View) Scale() int {
+	return v.column.scale
+}
@@ -0,0 +1,112 @@
+package ecolumn
+
+import (
+	"github.com/tobgu/qframe/aggregation"
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/index"
+	"github.com/tobgu/qframe/internal/scolumn"
+	"github.com/tobgu/qframe/qerrors"
+)
+
+// The built in aggregations operate on the enum values directly rather than on the strings
+// they represent. Since the number of possible values is limited they can be counted using
+// slices indexed by enum value. Order in min and max is the order of the enum values.
+//
+// Each aggregation is either a func(Column, index.Int) *string or a func(Column, index.Int) int.
+var aggregations = map[string]interface{}{
+	"min":            min,
+	"max":            max,
+	"first":          first,
+	"last":           last,
+	"mode":           mode,
+	"count_distinct": countDistinct,
+	"join":           join(scolumn.DefaultJoinSeparator),
+}
+
+func builtInAggregation(name string) (interface{}, error) {
+	if fn, ok := aggregations[name]; ok {
+		return fn, nil
+	}
+
+	if fnName, sep, ok := column.ParseParametric(name); ok && fnName == "join" {
+		return join(sep), nil
+	}
+
+	return nil, qerrors.New("enum aggregate", "aggregation function %s is not defined for enum column", name)
+}
+
+// Null is ignored by min and max, the result is null if all values are null.
+func min(c Column, ix index.Int) *string {
+	result := enumVal(nullValue)
+	for _, i := range ix {
+		if v := c.data.at(i); v < result {
+			result = v
+		}
+	}
+	return c.valuePtr(result)
+}
+
+func max(c Column, ix index.Int) *string {
+	result := enumVal(nullValue)
+	for _, i := range ix {
+		if v := c.data.at(i); !v.isNull() && (result.isNull() || v > result) {
+			result = v
+		}
+	}
+	return c.valuePtr(result)
+}
+
+func first(c Column, ix index.Int) *string {
+	return c.stringPtrAt(ix[0])
+}
+
+func last(c Column, ix index.Int) *string {
+	return c.stringPtrAt(ix[len(ix)-1])
+}
+
+// mode returns the most frequent non null value. If several values are equally
+// frequent the first of them in enum order is returned. The result is null if
+// all values are null.
+func mode(c Column, ix index.Int) *string {
+	// Null is not counted
+	counts := make([]int, len(c.values))
+	for _, i := range ix {
+		if v := c.data.at(i); !v.isNull() {
+			counts[v]++
+		}
+	}
+
+	result := enumVal(nullValue)
+	for v, count := range counts {
+		if count > 0 && (result.isNull() || count > counts[result]) {
+			result = enumVal(v)
+		}
+	}
+	return c.valuePtr(result)
+}
+
+// countDistinct returns the number of distinct values, null counts as one value.
+func countDistinct(c Column, ix index.Int) int {
+	// The last position is used for null
+	seen := make([]bool, len(c.values)+1)
+	result := 0
+	for _, i := range ix {
+		v := c.data.at(i)
+		if v.isNull() {
+			v = enumVal(len(c.values))
+		}
+
+		if !seen[v] {
+			seen[v] = true
+			result++
+		}
+	}
+	return result
+}
+
+func join(sep string) func(Column, index.Int) *string {
+	strJoin := aggregation.StrJoin(sep)
+	return func(c Column, ix index.Int) *string {
+		return strJoin(c.stringSlice(ix))
+	}
+}
@@ -3,16 +3,30 @@ package ecolumn
 import "fmt"
 
 // Helper type for multi value filtering
-type bitset [4]uint64
+type bitset []uint64
 
-func (s *bitset) set(val enumVal) {
+// newBitset returns a bitset large enough to hold size enum values.
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (s bitset) set(val enumVal) {
 	s[val>>6] |= 1 << (val & 0x3F)
 }
 
-func (s *bitset) isSet(val enumVal) bool {
-	return s[val>>6]&(1<<(val&0x3F)) > 0
+// isSet returns false for values outside of the bitset, eg. null.
+func (s bitset) isSet(val enumVal) bool {
+	ix := int(val >> 6)
+	return ix < len(s) && s[ix]&(1<<(val&0x3F)) > 0
 }
 
-func (s *bitset) String() string {
-	return fmt.Sprintf("%X %X %X %X", s[3], s[2], s[1], s[0])
+func (s bitset) String() string {
+	result := ""
+	for i := len(s) - 1; i >= 0; i-- {
+		if result != "" {
+			result += " "
+		}
+		result += fmt.Sprintf("%X", s[i])
+	}
+	return result
 }
@@ -2,7 +2,7 @@ package ecolumn
 
 import (
 	"fmt"
-	"github.com/tobgu/qframe/config/rolling"
+	"math"
 	"reflect"
 	"strings"
 
@@ -10,15 +10,18 @@ import (
 	"github.com/tobgu/qframe/internal/column"
 	"github.com/tobgu/qframe/internal/hash"
 	"github.com/tobgu/qframe/internal/index"
+	qfrolling "github.com/tobgu/qframe/internal/rolling"
 	"github.com/tobgu/qframe/internal/scolumn"
 	qfstrings "github.com/tobgu/qframe/internal/strings"
 	"github.com/tobgu/qframe/qerrors"
 	"github.com/tobgu/qframe/types"
 )
 
-type enumVal uint8
+type enumVal uint16
 
-const maxCardinality = 255
+// maxCardinality is the max number of distinct values in an enum column. Columns with
+// more than maxNarrowCardinality values use two bytes per value rather than one.
+const maxCardinality = math.MaxUint16
 const nullValue = maxCardinality
 
 func (v enumVal) isNull() bool {
@@ -36,7 +39,7 @@ func (v enumVal) compVal() int {
 }
 
 type Column struct {
-	data   []enumVal
+	data   enumData
 	values []string
 
 	// strict is set to true if the set of values has been defined rather than derived from the data.
@@ -86,6 +89,16 @@ func NewConst(val *string, count int, values []string) (Column, error) {
 	return f.ToColumn(), nil
 }
 
+// Const returns a column with count copies of val that can be appended to c without changing its values.
+// An error is returned if the values of c are fixed and val is not one of them.
+func (c Column) Const(val *string, count int) (Column, error) {
+	if c.strict {
+		return NewConst(val, count, c.values)
+	}
+
+	return NewConst(val, count, nil)
+}
+
 func NewFactory(values []string, sizeHint int) (*Factory, error) {
 	if len(values) > maxCardinality {
 		return nil, qerrors.New("New enum", "too many unique values, max cardinality is %d", maxCardinality)
@@ -101,7 +114,7 @@ func NewFactory(values []string, sizeHint int) (*Factory, error) {
 	}
 
 	return &Factory{column: Column{
-		data: make([]enumVal, 0, sizeHint), values: values, strict: len(values) > 0},
+		data: newEnumData(sizeHint, len(values) > maxNarrowCardinality), values: values, strict: len(values) > 0},
 		valToEnum: valToEnum}, nil
 }
 
@@ -110,7 +123,7 @@ func (f *Factory) AppendNil() {
 }
 
 func (f *Factory) AppendEnum(val enumVal) {
-	f.column.data = append(f.column.data, val)
+	f.column.data.append(val)
 }
 
 func (f *Factory) AppendByteString(str []byte) error {
@@ -125,7 +138,7 @@ func (f *Factory) AppendByteString(str []byte) error {
 
 func (f *Factory) AppendString(str string) error {
 	if e, ok := f.valToEnum[str]; ok {
-		f.column.data = append(f.column.data, e)
+		f.AppendEnum(e)
 		return nil
 	}
 
@@ -133,6 +146,10 @@ func (f *Factory) AppendString(str string) error {
 }
 
 func (f *Factory) newEnumVal(s string) enumVal {
+	if len(f.column.values) == maxNarrowCardinality {
+		f.column.data = f.column.data.widen()
+	}
+
 	ev := enumVal(len(f.column.values))
 	f.column.values = append(f.column.values, s)
 	f.valToEnum[s] = ev
@@ -168,8 +185,7 @@ func (f *Factory) appendString(str string) error {
 		return qerrors.New("append enum val", `enum max cardinality (%d) exceeded`, maxCardinality)
 	}
 
-	ev := f.newEnumVal(str)
-	f.column.data = append(f.column.data, ev)
+	f.AppendEnum(f.newEnumVal(str))
 	return nil
 }
 
@@ -198,11 +214,11 @@ func toUpper(_ index.Int, s Column) interface{} {
 }
 
 func (c Column) Len() int {
-	return len(c.data)
+	return c.data.len()
 }
 
 func (c Column) StringAt(i uint32, naRep string) string {
-	v := c.data[i]
+	v := c.data.at(i)
 	if v.isNull() {
 		return naRep
 	}
@@ -211,7 +227,7 @@ func (c Column) StringAt(i uint32, naRep string) string {
 }
 
 func (c Column) AppendByteStringAt(buf []byte, i uint32) []byte {
-	enum := c.data[i]
+	enum := c.data.at(i)
 	if enum.isNull() {
 		return append(buf, "null"...)
 	}
@@ -224,7 +240,7 @@ func (c Column) ByteSize() int {
 	for _, s := range c.values {
 		totalSize += len(s)
 	}
-	totalSize += cap(c.data)
+	totalSize += c.data.byteSize()
 	return totalSize
 }
 
@@ -235,8 +251,8 @@ func (c Column) Equals(index index.Int, other column.Column, otherIndex index.In
 	}
 
 	for ix, x := range index {
-		enumVal := c.data[x]
-		oEnumVal := otherE.data[otherIndex[ix]]
+		enumVal := c.data.at(x)
+		oEnumVal := otherE.data.at(otherIndex[ix])
 		if enumVal.isNull() || oEnumVal.isNull() {
 			if enumVal == oEnumVal {
 				continue
@@ -253,7 +269,7 @@ func (c Column) Equals(index index.Int, other column.Column, otherIndex index.In
 }
 
 func (c Comparable) Compare(i, j uint32) column.CompareResult {
-	x, y := c.column.data[i], c.column.data[j]
+	x, y := c.column.data.at(i), c.column.data.at(j)
 	if x.isNull() || y.isNull() {
 		if !x.isNull() {
 			return c.nullGtValue
@@ -278,12 +294,12 @@ func (c Comparable) Compare(i, j uint32) column.CompareResult {
 }
 
 func (c Comparable) Hash(i uint32, seed uint64) uint64 {
-	b := [1]byte{byte(c.column.data[i])}
-	return hash.HashBytes(b[:], seed)
+	var buf [2]byte
+	return hash.HashBytes(c.column.data.hashBytes(i, &buf), seed)
 }
 
 func equalTypes(s1, s2 Column) bool {
-	if len(s1.values) != len(s2.values) || len(s1.data) != len(s2.data) {
+	if len(s1.values) != len(s2.values) || s1.Len() != s2.Len() {
 		return false
 	}
 
@@ -296,10 +312,10 @@ func equalTypes(s1, s2 Column) bool {
 	return true
 }
 
-func (c Column) filterWithBitset(index index.Int, bset *bitset, bIndex index.Bool) {
+func (c Column) filterWithBitset(index index.Int, bset bitset, bIndex index.Bool) {
 	for i, x := range bIndex {
 		if !x {
-			enum := c.data[index[i]]
+			enum := c.data.at(index[i])
 			bIndex[i] = bset.isSet(enum)
 		}
 	}
@@ -312,7 +328,7 @@ func (c Column) filterBuiltIn(index index.Int, comparator string, comparatee int
 		if compFunc, ok := filterFuncs1[comparator]; ok {
 			for i, value := range c.values {
 				if value == comp {
-					compFunc(index, c.data, enumVal(i), bIndex)
+					compFunc(index, c, enumVal(i), bIndex)
 					return nil
 				}
 			}
@@ -363,14 +379,14 @@ func (c Column) filterBuiltIn(index index.Int, comparator string, comparatee int
 			return qerrors.New("filter enum", "unknown comparison operator for column - column comparison, %v", comparator)
 		}
 
-		compFunc(index, c.data, comp.data, bIndex)
+		compFunc(index, c, comp, bIndex)
 		return nil
 	case nil:
 		compFunc, ok := filterFuncs0[comparator]
 		if !ok {
 			return qerrors.New("filter enum", "unknown comparison operator for zero argument comparison, %v", comparator)
 		}
-		compFunc(index, c.data, bIndex)
+		compFunc(index, c, bIndex)
 		return nil
 	default:
 		return qerrors.New("filter enum", "invalid comparison type, %v, expected string or other enum column", reflect.TypeOf(comparatee))
@@ -416,22 +432,100 @@ func (c Column) Filter(index index.Int, comparator interface{}, comparatee inter
 }
 
 func (c Column) subset(index index.Int) Column {
-	data := make([]enumVal, 0, len(index))
-	for _, ix := range index {
-		data = append(data, c.data[ix])
-	}
-
-	return Column{data: data, values: c.values}
+	return Column{data: c.data.subset(index), values: c.values}
 }
 
 func (c Column) Subset(index index.Int) column.Column {
 	return c.subset(index)
 }
 
+func sameValues(v1, v2 []string) bool {
+	if len(v1) != len(v2) {
+		return false
+	}
+
+	for i, v := range v1 {
+		if v != v2[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Append returns a new column holding the data of this column followed by the data
+// of all columns in cols. All columns must be enum columns. If the value sets of the
+// columns differ they are merged, values of this column first. The result is only
+// strict if all columns with values defined are strict.
+func (c Column) Append(cols ...column.Column) (column.Column, error) {
+	size := c.Len()
+	strict := c.strict
+	values := c.values
+	valToEnum := make(map[string]enumVal, len(c.values))
+	for i, v := range c.values {
+		valToEnum[v] = enumVal(i)
+	}
+
+	remaps := make([][]enumVal, len(cols))
+	for i, col := range cols {
+		eCol, ok := col.(Column)
+		if !ok {
+			return nil, qerrors.New("enum.Append", "invalid column type: %s", col.DataType())
+		}
+
+		size += eCol.Len()
+		// A column without values can only contain nulls and does not affect strictness
+		strict = strict && (eCol.strict || len(eCol.values) == 0)
+		if sameValues(c.values, eCol.values) {
+			continue
+		}
+
+		remap := make([]enumVal, len(eCol.values))
+		for j, v := range eCol.values {
+			e, ok := valToEnum[v]
+			if !ok {
+				if len(values) >= maxCardinality {
+					return nil, qerrors.New("enum.Append", "enum max cardinality (%d) exceeded", maxCardinality)
+				}
+
+				if len(values) == len(c.values) {
+					// Copy on first new value to avoid modifying the values of this column
+					values = append(make([]string, 0, len(c.values)+len(eCol.values)), c.values...)
+				}
+
+				e = enumVal(len(values))
+				values = append(values, v)
+				valToEnum[v] = e
+			}
+			remap[j] = e
+		}
+		remaps[i] = remap
+	}
+
+	data := newEnumData(size, len(values) > maxNarrowCardinality)
+	for i := 0; i < c.Len(); i++ {
+		data.append(c.data.at(uint32(i)))
+	}
+
+	for i, col := range cols {
+		remap := remaps[i]
+		eCol := col.(Column)
+		for j := 0; j < eCol.Len(); j++ {
+			v := eCol.data.at(uint32(j))
+			if remap != nil && !v.isNull() {
+				v = remap[v]
+			}
+			data.append(v)
+		}
+	}
+
+	return Column{data: data, values: values, strict: strict}, nil
+}
+
 func (c Column) stringSlice(index index.Int) []*string {
 	result := make([]*string, 0, len(index))
 	for _, ix := range index {
-		v := c.data[ix]
+		v := c.data.at(ix)
 		if v.isNull() {
 			result = append(result, nil)
 		} else {
@@ -460,9 +554,9 @@ func (c Column) Comparable(reverse, equalNull, nullLast bool) column.Comparable
 }
 
 func (c Column) String() string {
-	strs := make([]string, len(c.data))
-	for i, v := range c.data {
-		if v.isNull() {
+	strs := make([]string, c.Len())
+	for i := range strs {
+		if v := c.data.at(uint32(i)); v.isNull() {
 			// For now
 			strs[i] = "null"
 		} else {
@@ -473,28 +567,42 @@ func (c Column) String() string {
 	return fmt.Sprintf("%v", strs)
 }
 
-func (c Column) Aggregate(indices []index.Int, fn interface{}) (column.Column, error) {
+func (c Column) Aggregate(indices []index.Int, fn interface{}) (interface{}, error) {
 	// NB! The result of aggregating over an enum column is a string column
+	if name, ok := fn.(string); ok {
+		var err error
+		if fn, err = builtInAggregation(name); err != nil {
+			return nil, err
+		}
+	}
+
 	switch t := fn.(type) {
-	case string:
-		// There are currently no build in aggregations for enums
-		return nil, qerrors.New("enum aggregate", "aggregation function %v is not defined for enum column", fn)
-	case func([]*string) *string:
+	case func(Column, index.Int) *string:
 		data := make([]*string, 0, len(indices))
 		for _, ix := range indices {
-			data = append(data, t(c.stringSlice(ix)))
+			data = append(data, t(c, ix))
 		}
 		return scolumn.New(data), nil
+	case func(Column, index.Int) int:
+		data := make([]int, 0, len(indices))
+		for _, ix := range indices {
+			data = append(data, t(c, ix))
+		}
+		return data, nil
 	default:
-		return nil, qerrors.New("enum aggregate", "invalid aggregation function type: %v", t)
+		return scolumn.AggregateStrings(indices, fn, c.stringSlice)
 	}
 }
 
-func (c Column) stringPtrAt(i uint32) *string {
-	if c.data[i].isNull() {
+func (c Column) valuePtr(v enumVal) *string {
+	if v.isNull() {
 		return nil
 	}
-	return &c.values[c.data[i]]
+	return &c.values[v]
+}
+
+func (c Column) stringPtrAt(i uint32) *string {
+	return c.valuePtr(c.data.at(i))
 }
 
 func (c Column) Apply1(fn interface{}, ix index.Int) (interface{}, error) {
@@ -507,25 +615,25 @@ func (c Column) Apply1(fn interface{}, ix index.Int) (interface{}, error) {
 	*/
 	switch t := fn.(type) {
 	case func(*string) int:
-		result := make([]int, len(c.data))
+		result := make([]int, c.Len())
 		for _, i := range ix {
 			result[i] = t(c.stringPtrAt(i))
 		}
 		return result, nil
 	case func(*string) float64:
-		result := make([]float64, len(c.data))
+		result := make([]float64, c.Len())
 		for _, i := range ix {
 			result[i] = t(c.stringPtrAt(i))
 		}
 		return result, nil
 	case func(*string) bool:
-		result := make([]bool, len(c.data))
+		result := make([]bool, c.Len())
 		for _, i := range ix {
 			result[i] = t(c.stringPtrAt(i))
 		}
 		return result, nil
 	case func(*string) *string:
-		result := make([]*string, len(c.data))
+		result := make([]*string, c.Len())
 		for _, i := range ix {
 			result[i] = t(c.stringPtrAt(i))
 		}
@@ -548,7 +656,7 @@ func (c Column) Apply2(fn interface{}, s2 column.Column, ix index.Int) (column.C
 
 	switch t := fn.(type) {
 	case func(*string, *string) *string:
-		result := make([]*string, len(c.data))
+		result := make([]*string, c.Len())
 		for _, i := range ix {
 			result[i] = t(c.stringPtrAt(i), s2S.stringPtrAt(i))
 		}
@@ -569,8 +677,34 @@ func (c Column) View(ix index.Int) View {
 	return View{column: c, index: ix}
 }
 
-func (c Column) Rolling(fn interface{}, ix index.Int, config rolling.Config) (column.Column, error) {
-	return c, nil
+func (c Column) Rolling(fn interface{}, ix index.Int, windows []qfrolling.Window, padValue interface{}) (column.Column, error) {
+	// NB! As for aggregations the result of rolling over an enum column is a string column
+	if name, ok := fn.(string); ok {
+		builtIn, err := builtInAggregation(name)
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := builtIn.(type) {
+		case func(Column, index.Int) *string:
+			fn = func(ix index.Int) *string { return t(c, ix) }
+		case func(Column, index.Int) int:
+			fn = func(ix index.Int) int { return t(c, ix) }
+		}
+	}
+
+	return scolumn.RollingStrings(fn, c.stringSlice, c.Len(), ix, windows, padValue)
+}
+
+func (c Column) IntervalWindows(fn interface{}, ix index.Int, position string) ([]qfrolling.Window, error) {
+	t, ok := fn.(func(*string, *string) bool)
+	if !ok {
+		return nil, qerrors.New("enum.IntervalWindows", "invalid interval function type: %v", fn)
+	}
+
+	return qfrolling.IntervalWindows(len(ix), position, func(i, j int) bool {
+		return t(c.stringPtrAt(ix[i]), c.stringPtrAt(ix[j]))
+	}), nil
 }
 
 func (c Column) FunctionType() types.FunctionType {
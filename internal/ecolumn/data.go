@@ -0,0 +1,109 @@
+package ecolumn
+
+import (
+	"math"
+
+	"github.com/tobgu/qframe/internal/index"
+)
+
+// enumData holds the enum values of a column. Values are stored in one byte each as long as
+// the number of distinct values allows it, two bytes otherwise. Only one of narrow and wide
+// is used, wide is nil for narrow data.
+type enumData struct {
+	narrow []uint8
+	wide   []uint16
+}
+
+// narrowNullValue is the representation of null in narrow data.
+const narrowNullValue = math.MaxUint8
+
+// maxNarrowCardinality is the max number of distinct values that can be stored in narrow data.
+const maxNarrowCardinality = narrowNullValue
+
+func newEnumData(capacity int, wide bool) enumData {
+	if wide {
+		return enumData{wide: make([]uint16, 0, capacity)}
+	}
+
+	return enumData{narrow: make([]uint8, 0, capacity)}
+}
+
+func (d enumData) isWide() bool {
+	return d.wide != nil
+}
+
+func (d enumData) len() int {
+	if d.isWide() {
+		return len(d.wide)
+	}
+
+	return len(d.narrow)
+}
+
+func (d enumData) at(i uint32) enumVal {
+	if d.isWide() {
+		return enumVal(d.wide[i])
+	}
+
+	v := d.narrow[i]
+	if v == narrowNullValue {
+		return nullValue
+	}
+
+	return enumVal(v)
+}
+
+// append adds v to the data. v must fit into the data, use widen to make room for more values.
+func (d *enumData) append(v enumVal) {
+	if d.isWide() {
+		d.wide = append(d.wide, uint16(v))
+		return
+	}
+
+	if v.isNull() {
+		d.narrow = append(d.narrow, narrowNullValue)
+		return
+	}
+
+	d.narrow = append(d.narrow, uint8(v))
+}
+
+// widen returns the data stored with two bytes per value.
+func (d enumData) widen() enumData {
+	if d.isWide() {
+		return d
+	}
+
+	result := newEnumData(cap(d.narrow), true)
+	for i := range d.narrow {
+		result.append(d.at(uint32(i)))
+	}
+
+	return result
+}
+
+func (d enumData) subset(ix index.Int) enumData {
+	result := newEnumData(len(ix), d.isWide())
+	for _, i := range ix {
+		result.append(d.at(i))
+	}
+
+	return result
+}
+
+// byteSize returns the size of the stored values, not including slice headers.
+func (d enumData) byteSize() int {
+	return cap(d.narrow) + 2*cap(d.wide)
+}
+
+// hashBytes returns the bytes representing the value at position i.
+func (d enumData) hashBytes(i uint32, buf *[2]byte) []byte {
+	if d.isWide() {
+		v := d.wide[i]
+		buf[0], buf[1] = byte(v), byte(v>>8)
+		return buf[:]
+	}
+
+	buf[0] = d.narrow[i]
+	return buf[:1]
+}
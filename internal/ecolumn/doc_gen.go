@@ -17,5 +17,13 @@ func Doc() string {
 		"  like\n" +
 
 		"\n Built in aggregations\n" +
+		"  count_distinct\n" +
+		"  first\n" +
+		"  join\n" +
+		"  last\n" +
+		"  max\n" +
+		"  min\n" +
+		"  mode\n" +
+		"  join(sep)\n" +
 		"\n"
 }
@@ -12,10 +12,10 @@ import (
 //go:generate qfgenerate -source=edoc -dst-file=doc_gen.go
 
 const basicColConstComparison = `
-func {{.name}}(index index.Int, column []enumVal, comparatee enumVal, bIndex index.Bool) {
+func {{.name}}(index index.Int, c Column, comparatee enumVal, bIndex index.Bool) {
 	for i, x := range bIndex {
 		if !x {
-			enum := column[index[i]]
+			enum := c.data.at(index[i])
 			bIndex[i] = !enum.isNull() && enum.compVal() {{.operator}} comparatee.compVal()
 		}
 	}
@@ -23,10 +23,10 @@ func {{.name}}(index index.Int, column []enumVal, comparatee enumVal, bIndex ind
 `
 
 const basicColColComparison = `
-func {{.name}}(index index.Int, col, col2 []enumVal, bIndex index.Bool) {
+func {{.name}}(index index.Int, col, col2 Column, bIndex index.Bool) {
 	for i, x := range bIndex {
 		if !x {
-			enum, enum2 := col[index[i]], col2[index[i]]
+			enum, enum2 := col.data.at(index[i]), col2.data.at(index[i])
 			bIndex[i] = !enum.isNull() && !enum2.isNull() && enum.compVal() {{.operator}} enum2.compVal()
 		}
 	}
@@ -69,5 +69,9 @@ func GenerateDoc() (*bytes.Buffer, error) {
 	return template.GenerateDocs(
 		"ecolumn",
 		maps.StringKeys(filterFuncs0, filterFuncs1, filterFuncs2, multiFilterFuncs, multiInputFilterFuncs),
-		maps.StringKeys())
+		aggregationNames())
+}
+
+func aggregationNames() []string {
+	return append(maps.StringKeys(aggregations), "join(sep)")
 }
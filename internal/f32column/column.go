@@ -0,0 +1,88 @@
+package f32column
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/fcolumn"
+	"github.com/tobgu/qframe/internal/ryu"
+	"github.com/tobgu/qframe/qerrors"
+	"github.com/tobgu/qframe/types"
+)
+
+func (c Column) DataType() types.DataType {
+	return types.Float32
+}
+
+func (c Column) FunctionType() types.FunctionType {
+	return types.FunctionTypeFloat
+}
+
+// FromFloats returns a new column with the values in d rounded to the closest float32.
+// An error is returned if any value is out of range for float32.
+func FromFloats(d []float64) (Column, error) {
+	data := make([]float32, len(d))
+	for i, x := range d {
+		v := float32(x)
+		if math.IsInf(float64(v), 0) && !math.IsInf(x, 0) {
+			return Column{}, qerrors.New("FromFloats", "value %v at row %d out of range for %s", x, i, types.Float32)
+		}
+		data[i] = v
+	}
+
+	return Column{data: data}, nil
+}
+
+// NewNull returns a new column with count null values.
+func NewNull(count int) Column {
+	data := make([]float32, count)
+	for i := range data {
+		data[i] = float32(math.NaN())
+	}
+
+	return Column{data: data}
+}
+
+// Widen returns the values of the column in a float column.
+func (c Column) Widen() column.Column {
+	data := make([]float64, len(c.data))
+	for i, x := range c.data {
+		data[i] = float64(x)
+	}
+
+	return fcolumn.New(data)
+}
+
+// comparatee returns the filter argument widened to match the widened column. Floats are
+// rounded to float32 precision first so that eg. 0.1 matches the stored value of 0.1.
+func (c Column) comparatee(comparatee interface{}) interface{} {
+	switch t := comparatee.(type) {
+	case Column:
+		return t.Widen()
+	case float64:
+		if math.IsNaN(t) {
+			return t
+		}
+		return float64(float32(t))
+	default:
+		return comparatee
+	}
+}
+
+func (c Column) StringAt(i uint32, naRep string) string {
+	value := c.data[i]
+	if value != value {
+		return naRep
+	}
+	return strconv.FormatFloat(float64(value), 'f', -1, 32)
+}
+
+func (c Column) AppendByteStringAt(buf []byte, i uint32) []byte {
+	value := c.data[i]
+	if value != value {
+		return append(buf, "null"...)
+	}
+
+	return ryu.AppendFloat32f(buf, value)
+}
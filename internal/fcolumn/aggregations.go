@@ -1,8 +1,78 @@
 package fcolumn
 
-var aggregations = map[string]func([]float64) float64{
-	"sum": sum,
-	"avg": avg,
+import (
+	"math"
+	"sort"
+
+	"github.com/tobgu/qframe/internal/math/float"
+)
+
+// NB! The slices passed to the built in aggregations are copies of the column
+// data owned by the caller. They may be reordered in place to avoid allocations.
+//
+// NaN is propagated to the result of all aggregations except for the "nan" variants
+// that skip NaN values. The "nan" variants return NaN if all values are NaN.
+
+var aggregations = map[string]interface{}{
+	"sum":            sum,
+	"avg":            avg,
+	"min":            min,
+	"max":            max,
+	"first":          first,
+	"last":           last,
+	"median":         median,
+	"std":            std,
+	"var":            variance,
+	"count_distinct": countDistinct,
+	"nunique":        nunique,
+	"nansum":         skipNaN(sum),
+	"nanavg":         skipNaN(avg),
+	"nanmin":         skipNaN(min),
+	"nanmax":         skipNaN(max),
+	"nanfirst":       skipNaN(first),
+	"nanlast":        skipNaN(last),
+	"nanmedian":      skipNaN(median),
+	"nanstd":         skipNaN(std),
+	"nanvar":         skipNaN(variance),
+}
+
+var quantileAggregations = map[string]func(q float64) interface{}{
+	"quantile": func(q float64) interface{} {
+		return quantileFn(q)
+	},
+	"nanquantile": func(q float64) interface{} {
+		return skipNaN(quantileFn(q))
+	},
+}
+
+// dropNaN moves all non NaN values to the beginning of values and returns that part of it.
+func dropNaN(values []float64) []float64 {
+	result := values[:0]
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func skipNaN(fn func([]float64) float64) func([]float64) float64 {
+	return func(values []float64) float64 {
+		values = dropNaN(values)
+		if len(values) == 0 {
+			return math.NaN()
+		}
+		return fn(values)
+	}
+}
+
+func hasNaN(values []float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
 }
 
 func sum(values []float64) float64 {
@@ -21,3 +91,94 @@ func avg(values []float64) float64 {
 
 	return result / float64(len(values))
 }
+
+func min(values []float64) float64 {
+	result := values[0]
+	for _, v := range values {
+		if math.IsNaN(v) {
+			return v
+		}
+
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
+
+func max(values []float64) float64 {
+	result := values[0]
+	for _, v := range values {
+		if math.IsNaN(v) {
+			return v
+		}
+
+		if v > result {
+			result = v
+		}
+	}
+	return result
+}
+
+func first(values []float64) float64 {
+	return values[0]
+}
+
+func last(values []float64) float64 {
+	return values[len(values)-1]
+}
+
+func quantileFn(q float64) func([]float64) float64 {
+	return func(values []float64) float64 {
+		if hasNaN(values) {
+			return math.NaN()
+		}
+
+		sort.Float64s(values)
+		return float.Quantile(values, q)
+	}
+}
+
+var median = quantileFn(0.5)
+
+// variance returns the sample variance of values, NaN for fewer than two values.
+func variance(values []float64) float64 {
+	if len(values) < 2 {
+		return math.NaN()
+	}
+
+	mean := avg(values)
+	result := 0.0
+	for _, v := range values {
+		d := v - mean
+		result += d * d
+	}
+	return result / float64(len(values)-1)
+}
+
+// std returns the sample standard deviation of values, NaN for fewer than two values.
+func std(values []float64) float64 {
+	return math.Sqrt(variance(values))
+}
+
+// countDistinct returns the number of distinct values, all NaNs count as one value.
+func countDistinct(values []float64) int {
+	// NaNs are sorted first
+	sort.Float64s(values)
+	result := 1
+	for i := 1; i < len(values); i++ {
+		if values[i] != values[i-1] && !math.IsNaN(values[i]) {
+			result++
+		}
+	}
+	return result
+}
+
+// nunique returns the number of distinct values, NaN is not counted.
+func nunique(values []float64) int {
+	values = dropNaN(values)
+	if len(values) == 0 {
+		return 0
+	}
+	return countDistinct(values)
+}
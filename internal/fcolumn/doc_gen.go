@@ -15,6 +15,26 @@ func Doc() string {
 
 		"\n Built in aggregations\n" +
 		"  avg\n" +
+		"  count_distinct\n" +
+		"  first\n" +
+		"  last\n" +
+		"  max\n" +
+		"  median\n" +
+		"  min\n" +
+		"  nanavg\n" +
+		"  nanfirst\n" +
+		"  nanlast\n" +
+		"  nanmax\n" +
+		"  nanmedian\n" +
+		"  nanmin\n" +
+		"  nanstd\n" +
+		"  nansum\n" +
+		"  nanvar\n" +
+		"  nunique\n" +
+		"  std\n" +
 		"  sum\n" +
+		"  var\n" +
+		"  nanquantile(q)\n" +
+		"  quantile(q)\n" +
 		"\n"
 }
@@ -49,5 +49,13 @@ func GenerateDoc() (*bytes.Buffer, error) {
 	return template.GenerateDocs(
 		"fcolumn",
 		maps.StringKeys(filterFuncs0, filterFuncs1, filterFuncs2),
-		maps.StringKeys(aggregations))
+		aggregationNames())
+}
+
+func aggregationNames() []string {
+	result := maps.StringKeys(aggregations)
+	for _, name := range maps.StringKeys(quantileAggregations) {
+		result = append(result, name+"(q)")
+	}
+	return result
 }
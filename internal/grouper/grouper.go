@@ -2,6 +2,7 @@ package grouper
 
 import (
 	"math/bits"
+	"sort"
 
 	"github.com/tobgu/qframe/internal/column"
 	"github.com/tobgu/qframe/internal/index"
@@ -9,7 +10,7 @@ import (
 )
 
 /*
-This package implements a basic hash table used for GroupBy and Distinct operations.
+This package implements a basic hash table used for GroupBy, Distinct, Join and Exists operations.
 
 Hashing is done using Go runtime memhash, collisions are handled using linear probing.
 
@@ -23,6 +24,7 @@ type tableEntry struct {
 	hash     uint32
 	firstPos uint32
 	occupied bool
+	matched  bool
 }
 
 type table struct {
@@ -107,6 +109,22 @@ func (t *table) insertEntry(i uint32) {
 	}
 }
 
+// lookup returns the entry matching position i or nil if no such entry exists.
+func (t *table) lookup(i uint32) *tableEntry {
+	hashSum := t.hash(i)
+	bitMask := uint64(len(t.entries) - 1)
+	for pos := uint64(hashSum) & bitMask; ; pos = (pos + 1) & bitMask {
+		e := &t.entries[pos]
+		if !e.occupied {
+			return nil
+		}
+
+		if e.hash == hashSum && equals(t.comparables, i, e.firstPos) {
+			return e
+		}
+	}
+}
+
 func newTable(sizeExp int, comparables []column.Comparable, collectIx bool) *table {
 	return &table{
 		entries:     make([]tableEntry, integer.Pow2(sizeExp)),
@@ -179,3 +197,62 @@ func Distinct(ix index.Int, comparables []column.Comparable) index.Int {
 
 	return result
 }
+
+// Join matches the positions in leftIx against the positions in rightIx using comparables.
+// The comparables must cover the positions of both indices. For every position in leftIx
+// the matching positions in rightIx, in the order they appear in rightIx, are returned (nil
+// if there is no match). Positions in rightIx not matched by any position in leftIx are
+// returned in ascending order.
+func Join(leftIx, rightIx index.Int, comparables []column.Comparable) ([]index.Int, index.Int, GroupStats) {
+	initialSizeExp := calculateInitialSizeExp(len(rightIx))
+	table := newTable(initialSizeExp, comparables, true)
+	for _, i := range rightIx {
+		table.insertEntry(i)
+	}
+
+	matches := make([]index.Int, len(leftIx))
+	for j, i := range leftIx {
+		if e := table.lookup(i); e != nil {
+			e.matched = true
+			if e.ix == nil {
+				matches[j] = index.Int{e.firstPos}
+			} else {
+				matches[j] = e.ix
+			}
+		}
+	}
+
+	unmatched := make(index.Int, 0)
+	for _, e := range table.entries {
+		if e.occupied && !e.matched {
+			if e.ix == nil {
+				unmatched = append(unmatched, e.firstPos)
+			} else {
+				unmatched = append(unmatched, e.ix...)
+			}
+		}
+	}
+	sort.Slice(unmatched, func(i, j int) bool { return unmatched[i] < unmatched[j] })
+
+	stats := table.stats
+	stats.LoadFactor = table.loadFactor
+	stats.GroupCount = int(table.groupCount)
+	return matches, unmatched, stats
+}
+
+// Exists reports, for every position in ix, if there is a position in otherIx with equal values according
+// to comparables. The comparables must cover the positions of both indices.
+func Exists(ix, otherIx index.Int, comparables []column.Comparable) []bool {
+	initialSizeExp := calculateInitialSizeExp(len(otherIx))
+	table := newTable(initialSizeExp, comparables, false)
+	for _, i := range otherIx {
+		table.insertEntry(i)
+	}
+
+	result := make([]bool, len(ix))
+	for j, i := range ix {
+		result[j] = table.lookup(i) != nil
+	}
+
+	return result
+}
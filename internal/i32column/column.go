@@ -0,0 +1,7 @@
+package i32column
+
+import "github.com/tobgu/qframe/types"
+
+func (c Column) DataType() types.DataType {
+	return types.Int32
+}
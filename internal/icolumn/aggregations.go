@@ -1,5 +1,40 @@
 package icolumn
 
+import (
+	"math"
+	"sort"
+
+	"github.com/tobgu/qframe/internal/math/float"
+)
+
+// NB! The slices passed to the built in aggregations are copies of the column
+// data owned by the caller. They may be reordered in place to avoid allocations.
+// Null values are not part of the slices, hence the slices may be empty for the
+// aggregations that do not return an int.
+
+var aggregations = map[string]interface{}{
+	"sum":            sum,
+	"min":            min,
+	"max":            max,
+	"first":          first,
+	"last":           last,
+	"avg":            avg,
+	"median":         median,
+	"std":            std,
+	"var":            variance,
+	"count_distinct": countDistinct,
+	"nunique":        countDistinct,
+}
+
+var quantileAggregations = map[string]func(q float64) interface{}{
+	"quantile": func(q float64) interface{} {
+		return func(values []int) float64 {
+			sort.Ints(values)
+			return quantile(values, q)
+		}
+	},
+}
+
 func sum(values []int) int {
 	result := 0
 	for _, v := range values {
@@ -8,6 +43,78 @@ func sum(values []int) int {
 	return result
 }
 
-var aggregations = map[string]func([]int) int{
-	"sum": sum,
+func min(values []int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
+
+func max(values []int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v > result {
+			result = v
+		}
+	}
+	return result
+}
+
+func first(values []int) int {
+	return values[0]
+}
+
+func last(values []int) int {
+	return values[len(values)-1]
+}
+
+func avg(values []int) float64 {
+	return float64(sum(values)) / float64(len(values))
+}
+
+func quantile(sorted []int, q float64) float64 {
+	return float.QuantileAt(len(sorted), func(i int) float64 { return float64(sorted[i]) }, q)
+}
+
+func median(values []int) float64 {
+	sort.Ints(values)
+	return quantile(values, 0.5)
+}
+
+// variance returns the sample variance of values, NaN for fewer than two values.
+func variance(values []int) float64 {
+	if len(values) < 2 {
+		return math.NaN()
+	}
+
+	mean := avg(values)
+	result := 0.0
+	for _, v := range values {
+		d := float64(v) - mean
+		result += d * d
+	}
+	return result / float64(len(values)-1)
+}
+
+// std returns the sample standard deviation of values, NaN for fewer than two values.
+func std(values []int) float64 {
+	return math.Sqrt(variance(values))
+}
+
+func countDistinct(values []int) int {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sort.Ints(values)
+	result := 1
+	for i := 1; i < len(values); i++ {
+		if values[i] != values[i-1] {
+			result++
+		}
+	}
+	return result
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/tobgu/qframe/internal/index"
 	"github.com/tobgu/qframe/qerrors"
 	"github.com/tobgu/qframe/types"
+	"math"
+	"math/rand"
 	"reflect"
 	"strconv"
 	"unsafe"
@@ -15,17 +17,25 @@ func (c Column) DataType() types.DataType {
 	return types.Int
 }
 
-func (c Column) StringAt(i uint32, _ string) string {
+func (c Column) StringAt(i uint32, naRep string) string {
+	if c.nulls.Contains(i) {
+		return naRep
+	}
+
 	return strconv.FormatInt(int64(c.data[i]), 10)
 }
 
 func (c Column) AppendByteStringAt(buf []byte, i uint32) []byte {
+	if c.nulls.Contains(i) {
+		return append(buf, "null"...)
+	}
+
 	return strconv.AppendInt(buf, int64(c.data[i]), 10)
 }
 
 func (c Column) ByteSize() int {
-	// Slice header + data
-	return 2*8 + 8*cap(c.data)
+	// Slice header + data + nulls
+	return 2*8 + 8*cap(c.data) + c.nulls.ByteSize()
 }
 
 func (c Column) Equals(index index.Int, other column.Column, otherIndex index.Int) bool {
@@ -35,7 +45,8 @@ func (c Column) Equals(index index.Int, other column.Column, otherIndex index.In
 	}
 
 	for ix, x := range index {
-		if c.data[x] != otherI.data[otherIndex[ix]] {
+		y := otherIndex[ix]
+		if c.data[x] != otherI.data[y] || c.nulls.Contains(x) != otherI.nulls.Contains(y) {
 			return false
 		}
 	}
@@ -43,16 +54,36 @@ func (c Column) Equals(index index.Int, other column.Column, otherIndex index.In
 	return true
 }
 
+// FloatSlice returns the data of the column as floats, null is represented by NaN.
 func (c Column) FloatSlice() []float64 {
 	result := make([]float64, len(c.data))
 	for i, v := range c.data {
-		result[i] = float64(v)
+		if c.nulls.Contains(uint32(i)) {
+			result[i] = math.NaN()
+		} else {
+			result[i] = float64(v)
+		}
 	}
 
 	return result
 }
 
 func (c Comparable) Compare(i, j uint32) column.CompareResult {
+	if c.nulls != nil {
+		xNull, yNull := c.nulls.Contains(i), c.nulls.Contains(j)
+		if xNull || yNull {
+			if !xNull {
+				return c.nullGtValue
+			}
+
+			if !yNull {
+				return c.nullLtValue
+			}
+
+			return c.equalNullValue
+		}
+	}
+
 	x, y := c.data[i], c.data[j]
 	if x < y {
 		return c.ltValue
@@ -66,6 +97,17 @@ func (c Comparable) Compare(i, j uint32) column.CompareResult {
 }
 
 func (c Comparable) Hash(i uint32, seed uint64) uint64 {
+	if c.nulls.Contains(i) {
+		if c.equalNullValue == column.NotEqual {
+			// Use a random value here to avoid hash collisions when
+			// we don't consider null to equal null.
+			return rand.Uint64()
+		}
+
+		b := [1]byte{0}
+		return hash.HashBytes(b[:], seed)
+	}
+
 	x := &c.data[i]
 	b := (*[8]byte)(unsafe.Pointer(x))[:]
 	return hash.HashBytes(b, seed)
@@ -154,7 +196,7 @@ func (c Column) filterBuiltIn(index index.Int, comparator string, comparatee int
 		if !ok {
 			return qerrors.New("filter int", "invalid comparison operator to zero argument filter, %v", comparator)
 		}
-		compFunc(index, c.data, bIndex)
+		compFunc(index, c, bIndex)
 	} else {
 		return qerrors.New("filter int", "invalid comparison value type %v", reflect.TypeOf(comparatee))
 	}
@@ -187,8 +229,13 @@ func (c Column) filterCustom2(index index.Int, fn func(int, int) bool, comparate
 
 func (c Column) Filter(index index.Int, comparator interface{}, comparatee interface{}, bIndex index.Bool) error {
 	var err error
+	nullPositions := c.unmatchedNulls(index, comparatee, bIndex)
 	switch t := comparator.(type) {
 	case string:
+		if comparatee == nil {
+			// Zero argument filters are the null filters, they decide on null values themselves
+			nullPositions = nil
+		}
 		err = c.filterBuiltIn(index, t, comparatee, bIndex)
 	case func(int) bool:
 		c.filterCustom1(index, t, bIndex)
@@ -197,6 +244,8 @@ func (c Column) Filter(index index.Int, comparator interface{}, comparatee inter
 	default:
 		err = qerrors.New("filter int", "invalid filter type %v", reflect.TypeOf(comparator))
 	}
+
+	maskNulls(nullPositions, comparator, bIndex)
 	return err
 }
 
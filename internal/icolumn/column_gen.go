@@ -10,15 +10,20 @@ package icolumn
 import (
 	"fmt"
 
-	"github.com/tobgu/qframe/config/rolling"
-
 	"github.com/tobgu/qframe/internal/column"
 	"github.com/tobgu/qframe/internal/index"
+	"github.com/tobgu/qframe/internal/nulls"
+	qfrolling "github.com/tobgu/qframe/internal/rolling"
 	"github.com/tobgu/qframe/qerrors"
 )
 
 type Column struct {
 	data []int
+
+	// nulls holds the positions of null values. It is only used by column types that cannot
+	// represent null using a special value, float columns use NaN and always leave it nil.
+	// The data at null positions is always the zero value.
+	nulls nulls.Set
 }
 
 func New(d []int) Column {
@@ -101,15 +106,37 @@ func (c Column) subset(index index.Int) Column {
 		data[i] = c.data[ix]
 	}
 
-	return Column{data: data}
+	return Column{data: data, nulls: c.nulls.Subset(index)}
 }
 
 func (c Column) Subset(index index.Int) column.Column {
 	return c.subset(index)
 }
 
+// Append returns a new column holding the data of this column followed by the data
+// of all columns in cols. All columns must be of the same type as this column.
+func (c Column) Append(cols ...column.Column) (column.Column, error) {
+	size := len(c.data)
+	for _, col := range cols {
+		if _, ok := col.(Column); !ok {
+			return nil, qerrors.New(c.fnName("Append"), "invalid column type: %s", col.DataType())
+		}
+		size += col.Len()
+	}
+
+	data := make([]int, 0, size)
+	data = append(data, c.data...)
+	nullSets, lengths := []nulls.Set{c.nulls}, []int{len(c.data)}
+	for _, col := range cols {
+		data = append(data, col.(Column).data...)
+		nullSets, lengths = append(nullSets, col.(Column).nulls), append(lengths, col.Len())
+	}
+
+	return Column{data: data, nulls: nulls.Concat(nullSets, lengths)}, nil
+}
+
 func (c Column) Comparable(reverse, equalNull, nullLast bool) column.Comparable {
-	result := Comparable{data: c.data, ltValue: column.LessThan, gtValue: column.GreaterThan, nullLtValue: column.LessThan, nullGtValue: column.GreaterThan, equalNullValue: column.NotEqual}
+	result := Comparable{data: c.data, nulls: c.nulls, ltValue: column.LessThan, gtValue: column.GreaterThan, nullLtValue: column.LessThan, nullGtValue: column.GreaterThan, equalNullValue: column.NotEqual}
 	if reverse {
 		result.ltValue, result.nullLtValue, result.gtValue, result.nullGtValue =
 			result.gtValue, result.nullGtValue, result.ltValue, result.nullLtValue
@@ -134,32 +161,99 @@ func (c Column) Len() int {
 	return len(c.data)
 }
 
-func (c Column) Aggregate(indices []index.Int, fn interface{}) (column.Column, error) {
-	var actualFn func([]int) int
-	var ok bool
+// builtInAggregation returns the built in aggregation function identified by name.
+// Quantile aggregations are identified by their name followed by the quantile, eg. quantile(0.9).
+func (c Column) builtInAggregation(name string) (interface{}, error) {
+	if fn, ok := aggregations[name]; ok {
+		return fn, nil
+	}
 
-	switch t := fn.(type) {
-	case string:
-		actualFn, ok = aggregations[t]
-		if !ok {
-			return nil, qerrors.New(c.fnName("Aggregate"), "aggregation function %c is not defined for column", fn)
+	if fnName, q, ok, err := column.ParseQuantile(name); ok {
+		if fn, ok := quantileAggregations[fnName]; ok {
+			if err != nil {
+				return nil, qerrors.Propagate(c.fnName("Aggregate"), err)
+			}
+			return fn(q), nil
 		}
-	case func([]int) int:
-		actualFn = t
-	default:
-		return nil, qerrors.New(c.fnName("Aggregate"), "invalid aggregation function type: %v", t)
 	}
 
-	data := make([]int, 0, len(indices))
+	return nil, qerrors.New(c.fnName("Aggregate"), "aggregation function %s is not defined for column", name)
+}
+
+// Aggregate applies fn to the data of each group in indices. The result is either a Column or, if the result
+// type of fn differs from the column type, a slice of the result type.
+func (c Column) Aggregate(indices []index.Int, fn interface{}) (interface{}, error) {
+	if name, ok := fn.(string); ok {
+		var err error
+		if fn, err = c.builtInAggregation(name); err != nil {
+			return nil, err
+		}
+	}
+
+	// NB! The type assertions below are not done in a type switch since the
+	//     result types may equal the column type in the generated code.
 	var buf []int
-	for _, ix := range indices {
-		subS := c.subsetWithBuf(ix, &buf)
-		data = append(data, actualFn(subS.data))
+	if t, ok := fn.(func([]int) int); ok {
+		var zero int
+		var resultNulls nulls.Set
+		data := make([]int, 0, len(indices))
+		for i, ix := range indices {
+			subS := c.subsetWithBuf(ix, &buf)
+			if c.nulls != nil && len(subS.data) == 0 {
+				// Only nulls in group
+				if resultNulls == nil {
+					resultNulls = nulls.New(len(indices))
+				}
+				resultNulls.Add(uint32(i))
+				data = append(data, zero)
+				continue
+			}
+
+			data = append(data, t(subS.data))
+		}
+		return Column{data: data, nulls: resultNulls}, nil
+	}
+
+	if t, ok := fn.(func([]int) int); ok {
+		data := make([]int, 0, len(indices))
+		for _, ix := range indices {
+			subS := c.subsetWithBuf(ix, &buf)
+			data = append(data, t(subS.data))
+		}
+		return data, nil
+	}
+
+	if t, ok := fn.(func([]int) float64); ok {
+		data := make([]float64, 0, len(indices))
+		for _, ix := range indices {
+			subS := c.subsetWithBuf(ix, &buf)
+			data = append(data, t(subS.data))
+		}
+		return data, nil
+	}
+
+	if t, ok := fn.(func([]int) bool); ok {
+		data := make([]bool, 0, len(indices))
+		for _, ix := range indices {
+			subS := c.subsetWithBuf(ix, &buf)
+			data = append(data, t(subS.data))
+		}
+		return data, nil
 	}
 
-	return Column{data: data}, nil
+	if t, ok := fn.(func([]int) *string); ok {
+		data := make([]*string, 0, len(indices))
+		for _, ix := range indices {
+			subS := c.subsetWithBuf(ix, &buf)
+			data = append(data, t(subS.data))
+		}
+		return data, nil
+	}
+
+	return nil, qerrors.New(c.fnName("Aggregate"), "invalid aggregation function type: %v", fn)
 }
 
+// subsetWithBuf returns the non null values at the positions in index, using buf for storage.
 func (c Column) subsetWithBuf(index index.Int, buf *[]int) Column {
 	if cap(*buf) < len(index) {
 		*buf = make([]int, 0, len(index))
@@ -167,22 +261,85 @@ func (c Column) subsetWithBuf(index index.Int, buf *[]int) Column {
 
 	data := (*buf)[:0]
 	for _, ix := range index {
-		data = append(data, c.data[ix])
+		if !c.nulls.Contains(ix) {
+			data = append(data, c.data[ix])
+		}
 	}
 
 	return Column{data: data}
 }
 
 func (c Column) View(ix index.Int) View {
-	return View{data: c.data, index: ix}
+	return View{data: c.data, nulls: c.nulls, index: ix}
+}
+
+// Rolling applies fn to the data of each window. The result for window i is written to position ix[i],
+// or padValue if the window is incomplete and padValue has been set.
+func (c Column) Rolling(fn interface{}, ix index.Int, windows []qfrolling.Window, padValue interface{}) (column.Column, error) {
+	if name, ok := fn.(string); ok {
+		var err error
+		if fn, err = c.builtInAggregation(name); err != nil {
+			return nil, err
+		}
+
+		if _, ok := fn.(func([]int) int); !ok {
+			return nil, qerrors.New(c.fnName("Rolling"), "aggregation function %s has a different result type than the column", name)
+		}
+	}
+
+	actualFn, ok := fn.(func([]int) int)
+	if !ok {
+		return nil, qerrors.New(c.fnName("Rolling"), "invalid rolling function type: %v", fn)
+	}
+
+	var pad int
+	if padValue != nil {
+		pad, ok = padValue.(int)
+		if !ok {
+			return nil, qerrors.New(c.fnName("Rolling"), "invalid pad value type: %v", padValue)
+		}
+	}
+
+	data := make([]int, len(c.data))
+	var resultNulls nulls.Set
+	var buf []int
+	for i, w := range windows {
+		if !w.Complete && padValue != nil {
+			data[ix[i]] = pad
+			continue
+		}
+
+		subS := c.subsetWithBuf(ix[w.Start:w.End], &buf)
+		if c.nulls != nil && len(subS.data) == 0 {
+			// Only nulls in window
+			if resultNulls == nil {
+				resultNulls = nulls.New(len(c.data))
+			}
+			resultNulls.Add(ix[i])
+			continue
+		}
+
+		data[ix[i]] = actualFn(subS.data)
+	}
+
+	return Column{data: data, nulls: resultNulls}, nil
 }
 
-func (c Column) Rolling(fn interface{}, ix index.Int, config rolling.Config) (column.Column, error) {
-	return c, nil
+// IntervalWindows returns the windows given by the interval function fn for the positions in ix.
+func (c Column) IntervalWindows(fn interface{}, ix index.Int, position string) ([]qfrolling.Window, error) {
+	t, ok := fn.(func(int, int) bool)
+	if !ok {
+		return nil, qerrors.New(c.fnName("IntervalWindows"), "invalid interval function type: %v", fn)
+	}
+
+	return qfrolling.IntervalWindows(len(ix), position, func(i, j int) bool {
+		return t(c.data[ix[i]], c.data[ix[j]])
+	}), nil
 }
 
 type Comparable struct {
 	data           []int
+	nulls          nulls.Set
 	ltValue        column.CompareResult
 	nullLtValue    column.CompareResult
 	gtValue        column.CompareResult
@@ -193,10 +350,12 @@ type Comparable struct {
 // View is a view into a column that allows access to individual elements by index.
 type View struct {
 	data  []int
+	nulls nulls.Set
 	index index.Int
 }
 
-// ItemAt returns the value at position i.
+// ItemAt returns the value at position i. Null values are returned as the zero value,
+// use IsNull to tell them apart in int and bool columns.
 func (v View) ItemAt(i int) int {
 	return v.data[v.index[i]]
 }
@@ -13,8 +13,21 @@ func Doc() string {
 		"  all_bits\n" +
 		"  any_bits\n" +
 		"  in\n" +
+		"  isnotnull\n" +
+		"  isnull\n" +
 
 		"\n Built in aggregations\n" +
+		"  avg\n" +
+		"  count_distinct\n" +
+		"  first\n" +
+		"  last\n" +
+		"  max\n" +
+		"  median\n" +
+		"  min\n" +
+		"  nunique\n" +
+		"  std\n" +
 		"  sum\n" +
+		"  var\n" +
+		"  quantile(q)\n" +
 		"\n"
 }
@@ -48,6 +48,14 @@ func GenerateFilters() (*bytes.Buffer, error) {
 func GenerateDoc() (*bytes.Buffer, error) {
 	return template.GenerateDocs(
 		"icolumn",
-		maps.StringKeys(filterFuncs, filterFuncs2, multiInputFilterFuncs),
-		maps.StringKeys(aggregations))
+		maps.StringKeys(filterFuncs0, filterFuncs, filterFuncs2, multiInputFilterFuncs),
+		aggregationNames())
+}
+
+func aggregationNames() []string {
+	result := maps.StringKeys(aggregations)
+	for _, name := range maps.StringKeys(quantileAggregations) {
+		result = append(result, name+"(q)")
+	}
+	return result
 }
@@ -1,13 +1,29 @@
 package io
 
 import (
+	"fmt"
 	"io"
 	"math"
+	"time"
 
+	"github.com/tobgu/qframe/decimal"
+	"github.com/tobgu/qframe/internal/bcolumn"
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/dcolumn"
 	"github.com/tobgu/qframe/internal/ecolumn"
+	"github.com/tobgu/qframe/internal/f32column"
 	"github.com/tobgu/qframe/internal/fastcsv"
+	"github.com/tobgu/qframe/internal/i16column"
+	"github.com/tobgu/qframe/internal/i32column"
+	"github.com/tobgu/qframe/internal/i8column"
+	"github.com/tobgu/qframe/internal/icolumn"
 	"github.com/tobgu/qframe/internal/ncolumn"
+	"github.com/tobgu/qframe/internal/nulls"
 	"github.com/tobgu/qframe/internal/strings"
+	"github.com/tobgu/qframe/internal/tcolumn"
+	"github.com/tobgu/qframe/internal/u16column"
+	"github.com/tobgu/qframe/internal/u32column"
+	"github.com/tobgu/qframe/internal/u8column"
 	"github.com/tobgu/qframe/qerrors"
 	"github.com/tobgu/qframe/types"
 )
@@ -26,6 +42,9 @@ type CSVConfig struct {
 	EnumVals         map[string][]string
 	RowCountHint     int
 	Headers          []string
+	TimeLayout       string
+	TimeLocation     *time.Location
+	InferNullable    bool
 }
 
 func isEmptyLine(fields [][]byte) bool {
@@ -146,6 +165,9 @@ func resizeColBytes(bytes [][]byte, currentRowCount, sizeHint int) {
 }
 
 // Convert bytes to data columns, try, in turn int, float, bool and last string.
+// Empty fields are null in int, float, bool and time columns. Columns with empty fields are only
+// inferred to be int or bool columns if InferNullable is set, see nullableAllowed. Time columns
+// are never inferred.
 func columnToData(bytes []byte, pointers []bytePointer, colName string, conf CSVConfig) (interface{}, error) {
 	var err error
 	dataType := conf.Types[colName]
@@ -154,19 +176,20 @@ func columnToData(bytes []byte, pointers []bytePointer, colName string, conf CSV
 		return ncolumn.Column{}, nil
 	}
 
-	if dataType == types.Int || dataType == types.None {
-		intData := make([]int, 0, len(pointers))
-		for _, p := range pointers {
-			x, intErr := strings.ParseInt(bytes[p.start:p.end])
-			if intErr != nil {
-				err = intErr
-				break
-			}
-			intData = append(intData, x)
+	if narrowFn, ok := narrowColumns[dataType]; ok {
+		col, err := narrowFn(bytes, pointers)
+		if err != nil {
+			return nil, qerrors.Propagate(fmt.Sprintf("Create %s column", dataType), err)
 		}
+		return col, nil
+	}
 
-		if err == nil {
-			return intData, nil
+	if dataType == types.Int || dataType == types.None {
+		var intData []int
+		var nullSet nulls.Set
+		intData, nullSet, err = parseInts(bytes, pointers)
+		if err == nil && nullableAllowed(dataType, pointers, conf) {
+			return icolumn.NewNullable(intData, nullSet), nil
 		}
 
 		if dataType == types.Int {
@@ -175,22 +198,8 @@ func columnToData(bytes []byte, pointers []bytePointer, colName string, conf CSV
 	}
 
 	if dataType == types.Float || dataType == types.None {
-		err = nil
-		floatData := make([]float64, 0, len(pointers))
-		for _, p := range pointers {
-			if p.start == p.end {
-				floatData = append(floatData, math.NaN())
-				continue
-			}
-
-			x, floatErr := strings.ParseFloat(bytes[p.start:p.end])
-			if floatErr != nil {
-				err = floatErr
-				break
-			}
-			floatData = append(floatData, x)
-		}
-
+		var floatData []float64
+		floatData, err = parseFloats(bytes, pointers)
 		if err == nil {
 			return floatData, nil
 		}
@@ -203,7 +212,14 @@ func columnToData(bytes []byte, pointers []bytePointer, colName string, conf CSV
 	if dataType == types.Bool || dataType == types.None {
 		err = nil
 		boolData := make([]bool, 0, len(pointers))
-		for _, p := range pointers {
+		var nullSet nulls.Set
+		for i, p := range pointers {
+			if p.start == p.end {
+				nullSet = addNull(nullSet, i, len(pointers))
+				boolData = append(boolData, false)
+				continue
+			}
+
 			x, boolErr := strings.ParseBool(bytes[p.start:p.end])
 			if boolErr != nil {
 				err = boolErr
@@ -212,8 +228,8 @@ func columnToData(bytes []byte, pointers []bytePointer, colName string, conf CSV
 			boolData = append(boolData, x)
 		}
 
-		if err == nil {
-			return boolData, nil
+		if err == nil && nullableAllowed(dataType, pointers, conf) {
+			return bcolumn.NewNullable(boolData, nullSet), nil
 		}
 
 		if dataType == types.Bool {
@@ -234,6 +250,50 @@ func columnToData(bytes []byte, pointers []bytePointer, colName string, conf CSV
 		return strings.StringBlob{Pointers: stringPointers, Data: bytes}, nil
 	}
 
+	if dataType == types.Time {
+		timeData := make([]int64, 0, len(pointers))
+		var nullSet nulls.Set
+		for i, p := range pointers {
+			if p.start == p.end {
+				nullSet = addNull(nullSet, i, len(pointers))
+				timeData = append(timeData, 0)
+				continue
+			}
+
+			t, err := time.ParseInLocation(conf.TimeLayout, string(bytes[p.start:p.end]), conf.TimeLocation)
+			if err != nil {
+				return nil, qerrors.Propagate("Create time column", err)
+			}
+			timeData = append(timeData, t.UnixNano())
+		}
+
+		return tcolumn.NewNullable(timeData, nullSet, conf.TimeLocation), nil
+	}
+
+	if dataType == types.Decimal {
+		decimalData := make([]decimal.Decimal, 0, len(pointers))
+		var nullSet nulls.Set
+		for i, p := range pointers {
+			if p.start == p.end {
+				nullSet = addNull(nullSet, i, len(pointers))
+				decimalData = append(decimalData, decimal.Decimal{})
+				continue
+			}
+
+			d, err := decimal.Parse(strings.UnsafeBytesToString(bytes[p.start:p.end]))
+			if err != nil {
+				return nil, qerrors.Propagate("Create decimal column", err)
+			}
+			decimalData = append(decimalData, d)
+		}
+
+		col, err := dcolumn.NewNullable(decimalData, nullSet)
+		if err != nil {
+			return nil, qerrors.Propagate("Create decimal column", err)
+		}
+		return col, nil
+	}
+
 	if dataType == types.Enum {
 		values := conf.EnumVals[colName]
 		delete(conf.EnumVals, colName)
@@ -258,3 +318,111 @@ func columnToData(bytes []byte, pointers []bytePointer, colName string, conf CSV
 
 	return nil, qerrors.New("Create column", "unknown data type: %s", dataType)
 }
+
+// parseInts parses the fields pointed to as ints, empty fields are null.
+func parseInts(bytes []byte, pointers []bytePointer) ([]int, nulls.Set, error) {
+	intData := make([]int, 0, len(pointers))
+	var nullSet nulls.Set
+	for i, p := range pointers {
+		if p.start == p.end {
+			nullSet = addNull(nullSet, i, len(pointers))
+			intData = append(intData, 0)
+			continue
+		}
+
+		x, err := strings.ParseInt(bytes[p.start:p.end])
+		if err != nil {
+			return nil, nil, err
+		}
+		intData = append(intData, x)
+	}
+
+	return intData, nullSet, nil
+}
+
+// parseFloats parses the fields pointed to as floats, empty fields are NaN.
+func parseFloats(bytes []byte, pointers []bytePointer) ([]float64, error) {
+	floatData := make([]float64, 0, len(pointers))
+	for _, p := range pointers {
+		if p.start == p.end {
+			floatData = append(floatData, math.NaN())
+			continue
+		}
+
+		x, err := strings.ParseFloat(bytes[p.start:p.end])
+		if err != nil {
+			return nil, err
+		}
+		floatData = append(floatData, x)
+	}
+
+	return floatData, nil
+}
+
+func narrowInts(fromInts func([]int, nulls.Set) (column.Column, error)) func([]byte, []bytePointer) (column.Column, error) {
+	return func(bytes []byte, pointers []bytePointer) (column.Column, error) {
+		intData, nullSet, err := parseInts(bytes, pointers)
+		if err != nil {
+			return nil, err
+		}
+		return fromInts(intData, nullSet)
+	}
+}
+
+// narrowColumns holds the functions creating columns of the narrow types, they are never inferred.
+var narrowColumns = map[types.DataType]func([]byte, []bytePointer) (column.Column, error){
+	types.Int8:   narrowInts(func(d []int, n nulls.Set) (column.Column, error) { return i8column.FromInts(d, n) }),
+	types.Int16:  narrowInts(func(d []int, n nulls.Set) (column.Column, error) { return i16column.FromInts(d, n) }),
+	types.Int32:  narrowInts(func(d []int, n nulls.Set) (column.Column, error) { return i32column.FromInts(d, n) }),
+	types.Uint8:  narrowInts(func(d []int, n nulls.Set) (column.Column, error) { return u8column.FromInts(d, n) }),
+	types.Uint16: narrowInts(func(d []int, n nulls.Set) (column.Column, error) { return u16column.FromInts(d, n) }),
+	types.Uint32: narrowInts(func(d []int, n nulls.Set) (column.Column, error) { return u32column.FromInts(d, n) }),
+	types.Float32: func(bytes []byte, pointers []bytePointer) (column.Column, error) {
+		floatData, err := parseFloats(bytes, pointers)
+		if err != nil {
+			return nil, err
+		}
+		return f32column.FromFloats(floatData)
+	},
+}
+
+// addNull adds position i to nullSet, allocating a set that fits size positions if needed.
+func addNull(nullSet nulls.Set, i, size int) nulls.Set {
+	if nullSet == nil {
+		nullSet = nulls.New(size)
+	}
+	nullSet.Add(uint32(i))
+	return nullSet
+}
+
+// nullableAllowed returns true if an int or bool column may be created from the fields pointed to.
+// This is always the case for explicitly typed columns. Inferred columns may only contain empty fields
+// if InferNullable is set and at least one field is non empty, otherwise they are inferred as float and
+// string columns.
+func nullableAllowed(dataType types.DataType, pointers []bytePointer, conf CSVConfig) bool {
+	if dataType != types.None || !hasEmpty(pointers) {
+		return true
+	}
+
+	return conf.InferNullable && hasValues(pointers)
+}
+
+// hasEmpty returns true if any of the fields pointed to is empty.
+func hasEmpty(pointers []bytePointer) bool {
+	for _, p := range pointers {
+		if p.start == p.end {
+			return true
+		}
+	}
+	return false
+}
+
+// hasValues returns true if any of the fields pointed to is non empty.
+func hasValues(pointers []bytePointer) bool {
+	for _, p := range pointers {
+		if p.start != p.end {
+			return true
+		}
+	}
+	return false
+}
@@ -2,30 +2,48 @@ package io
 
 import (
 	"encoding/json"
+	"github.com/tobgu/qframe/internal/bcolumn"
+	"github.com/tobgu/qframe/internal/icolumn"
+	"github.com/tobgu/qframe/internal/nulls"
 	"github.com/tobgu/qframe/qerrors"
 	"io"
+	"math"
 )
 
 type JSONRecords []map[string]interface{}
 
 type JSONColumns map[string]json.RawMessage
 
-func fillInts(col []int, records JSONRecords, colName string) error {
+func fillInts(col []int, records JSONRecords, colName string) (nulls.Set, error) {
+	var nullSet nulls.Set
 	for i := range col {
 		record := records[i]
 		value, ok := record[colName]
 		if !ok {
-			return qerrors.New("fillInts", "missing value for column %s, row %d", colName, i)
+			return nil, qerrors.New("fillInts", "missing value for column %s, row %d", colName, i)
 		}
 
-		intValue, ok := value.(int)
+		if value == nil {
+			if nullSet == nil {
+				nullSet = nulls.New(len(col))
+			}
+			nullSet.Add(uint32(i))
+			continue
+		}
+
+		number, ok := value.(json.Number)
 		if !ok {
-			return qerrors.New("fillInts", "wrong type for column %s, row %d, expected int", colName, i)
+			return nil, qerrors.New("fillInts", "wrong type for column %s, row %d, expected int", colName, i)
 		}
-		col[i] = intValue
+
+		intValue, err := number.Int64()
+		if err != nil {
+			return nil, qerrors.New("fillInts", "wrong type for column %s, row %d, expected int", colName, i)
+		}
+		col[i] = int(intValue)
 	}
 
-	return nil
+	return nullSet, nil
 }
 
 func fillFloats(col []float64, records JSONRecords, colName string) error {
@@ -36,32 +54,51 @@ func fillFloats(col []float64, records JSONRecords, colName string) error {
 			return qerrors.New("fillFloats", "missing value for column %s, row %d", colName, i)
 		}
 
-		floatValue, ok := value.(float64)
+		if value == nil {
+			col[i] = math.NaN()
+			continue
+		}
+
+		number, ok := value.(json.Number)
 		if !ok {
 			return qerrors.New("fillFloats", "wrong type for column %s, row %d, expected float", colName, i)
 		}
+
+		floatValue, err := number.Float64()
+		if err != nil {
+			return qerrors.New("fillFloats", "wrong type for column %s, row %d, expected float", colName, i)
+		}
 		col[i] = floatValue
 	}
 
 	return nil
 }
 
-func fillBools(col []bool, records JSONRecords, colName string) error {
+func fillBools(col []bool, records JSONRecords, colName string) (nulls.Set, error) {
+	var nullSet nulls.Set
 	for i := range col {
 		record := records[i]
 		value, ok := record[colName]
 		if !ok {
-			return qerrors.New("fillBools", "wrong type for column %s, row %d", colName, i)
+			return nil, qerrors.New("fillBools", "wrong type for column %s, row %d", colName, i)
+		}
+
+		if value == nil {
+			if nullSet == nil {
+				nullSet = nulls.New(len(col))
+			}
+			nullSet.Add(uint32(i))
+			continue
 		}
 
 		boolValue, ok := value.(bool)
 		if !ok {
-			return qerrors.New("fillBools", "wrong type for column %s, row %d, expected bool", colName, i)
+			return nil, qerrors.New("fillBools", "wrong type for column %s, row %d, expected bool", colName, i)
 		}
 		col[i] = boolValue
 	}
 
-	return nil
+	return nullSet, nil
 }
 
 func fillStrings(col []*string, records JSONRecords, colName string) error {
@@ -85,22 +122,73 @@ func fillStrings(col []*string, records JSONRecords, colName string) error {
 	return nil
 }
 
-func jsonRecordsToData(records JSONRecords) (map[string]interface{}, error) {
+// fillNumberStrings fills col with the textual representation of the numbers in column colName.
+// Strings are accepted as well to support numbers that have been quoted to preserve their precision.
+func fillNumberStrings(col []*string, records JSONRecords, colName string) error {
+	for i := range col {
+		record := records[i]
+		value, ok := record[colName]
+		if !ok {
+			return qerrors.New("fillNumberStrings", "missing value for column %s, row %d", colName, i)
+		}
+
+		switch t := value.(type) {
+		case json.Number:
+			s := t.String()
+			col[i] = &s
+		case string:
+			col[i] = &t
+		case nil:
+			col[i] = nil
+		default:
+			return qerrors.New("fillNumberStrings", "wrong type for column %s, row %d, expected number", colName, i)
+		}
+	}
+
+	return nil
+}
+
+// firstValue returns the first non null value in column colName, nil if there is none.
+func firstValue(records JSONRecords, colName string) interface{} {
+	for _, record := range records {
+		if value := record[colName]; value != nil {
+			return value
+		}
+	}
+
+	return nil
+}
+
+// jsonRecordsToData converts records into columns. The type of each column is decided by its first
+// non null value. Numbers result in float columns, or int columns if inferInts is set and all of them
+// are integers. The values of the columns in decimalColumns are returned as text to be parsed exactly.
+func jsonRecordsToData(records JSONRecords, decimalColumns map[string]struct{}, inferInts bool) (map[string]interface{}, error) {
 	result := map[string]interface{}{}
 	if len(records) == 0 {
 		return result, nil
 	}
 
 	r0 := records[0]
-	for colName, value := range r0 {
-		switch t := value.(type) {
-		case int:
-			col := make([]int, len(records))
-			if err := fillInts(col, records, colName); err != nil {
+	for colName := range r0 {
+		if _, ok := decimalColumns[colName]; ok {
+			col := make([]*string, len(records))
+			if err := fillNumberStrings(col, records, colName); err != nil {
 				return nil, err
 			}
 			result[colName] = col
-		case float64:
+			continue
+		}
+
+		switch t := firstValue(records, colName).(type) {
+		case json.Number:
+			if inferInts {
+				intCol := make([]int, len(records))
+				if nullSet, err := fillInts(intCol, records, colName); err == nil {
+					result[colName] = icolumn.NewNullable(intCol, nullSet)
+					continue
+				}
+			}
+
 			col := make([]float64, len(records))
 			if err := fillFloats(col, records, colName); err != nil {
 				return nil, err
@@ -108,10 +196,11 @@ func jsonRecordsToData(records JSONRecords) (map[string]interface{}, error) {
 			result[colName] = col
 		case bool:
 			col := make([]bool, len(records))
-			if err := fillBools(col, records, colName); err != nil {
+			nullSet, err := fillBools(col, records, colName)
+			if err != nil {
 				return nil, err
 			}
-			result[colName] = col
+			result[colName] = bcolumn.NewNullable(col, nullSet)
 		case nil, string:
 			col := make([]*string, len(records))
 			if err := fillStrings(col, records, colName); err != nil {
@@ -126,14 +215,16 @@ func jsonRecordsToData(records JSONRecords) (map[string]interface{}, error) {
 }
 
 // UnmarshalJSON transforms JSON containing data records or columns into a map of columns
-// that can be used to create a QFrame.
-func UnmarshalJSON(r io.Reader) (map[string]interface{}, error) {
+// that can be used to create a QFrame. The numbers of the columns in decimalColumns are kept as text.
+// Integer columns are only inferred if inferInts is set.
+func UnmarshalJSON(r io.Reader, decimalColumns map[string]struct{}, inferInts bool) (map[string]interface{}, error) {
 	var records JSONRecords
 	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
 	err := decoder.Decode(&records)
 	if err != nil {
 		return nil, qerrors.Propagate("UnmarshalJSON", err)
 	}
 
-	return jsonRecordsToData(records)
+	return jsonRecordsToData(records, decimalColumns, inferInts)
 }
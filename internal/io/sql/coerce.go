@@ -2,6 +2,9 @@ package sql
 
 import (
 	"reflect"
+	"strconv"
+
+	"github.com/tobgu/qframe/decimal"
 
 	"github.com/tobgu/qframe/qerrors"
 )
@@ -16,6 +19,9 @@ type CoerceFunc func(c *Column) func(t interface{}) error
 // BOOL as INT types natively.
 func Int64ToBool(c *Column) func(t interface{}) error {
 	return func(t interface{}) error {
+		if t == nil {
+			return c.Null()
+		}
 		v, ok := t.(int64)
 		if !ok {
 			return qerrors.New(
@@ -25,3 +31,34 @@ func Int64ToBool(c *Column) func(t interface{}) error {
 		return nil
 	}
 }
+
+// TextToDecimal parses the textual representation of a number
+// into an exact decimal. This is useful for NUMERIC and DECIMAL
+// columns which most drivers return as text to preserve precision.
+func TextToDecimal(c *Column) func(t interface{}) error {
+	return func(t interface{}) error {
+		var text string
+		switch v := t.(type) {
+		case nil:
+			return c.Null()
+		case []uint8:
+			text = string(v)
+		case string:
+			text = v
+		case int64:
+			text = strconv.FormatInt(v, 10)
+		case float64:
+			text = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return qerrors.New(
+				"Coercion TextToDecimal", "type %s cannot be converted to decimal", reflect.TypeOf(t).Kind())
+		}
+
+		d, err := decimal.Parse(text)
+		if err != nil {
+			return qerrors.Propagate("Coercion TextToDecimal", err)
+		}
+		c.Decimal(d)
+		return nil
+	}
+}
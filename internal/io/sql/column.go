@@ -3,8 +3,13 @@ package sql
 import (
 	"math"
 	"reflect"
+	"time"
 
+	"github.com/tobgu/qframe/decimal"
+	"github.com/tobgu/qframe/internal/bcolumn"
+	"github.com/tobgu/qframe/internal/icolumn"
 	"github.com/tobgu/qframe/internal/math/float"
+	"github.com/tobgu/qframe/internal/nulls"
 
 	"github.com/tobgu/qframe/qerrors"
 )
@@ -15,14 +20,19 @@ import (
 type Column struct {
 	kind  reflect.Kind
 	nulls int
+	// positions of NULL values in int and bool
+	// columns which cannot represent them in data
+	nullRows []uint32
 	// pointer to the data slice which
 	// contains the inferred data type
 	ptr  interface{}
 	data struct {
-		Ints    []int
-		Floats  []float64
-		Bools   []bool
-		Strings []*string
+		Ints     []int
+		Floats   []float64
+		Bools    []bool
+		Strings  []*string
+		Times    []*time.Time
+		Decimals []*decimal.Decimal
 	}
 	coerce    func(t interface{}) error
 	precision int
@@ -45,6 +55,18 @@ func (c *Column) Null() error {
 		c.data.Floats = append(c.data.Floats, math.NaN())
 	case reflect.String:
 		c.data.Strings = append(c.data.Strings, nil)
+	case reflect.Struct:
+		if c.ptr == &c.data.Decimals {
+			c.data.Decimals = append(c.data.Decimals, nil)
+		} else {
+			c.data.Times = append(c.data.Times, nil)
+		}
+	case reflect.Int:
+		c.nullRows = append(c.nullRows, uint32(len(c.data.Ints)))
+		c.data.Ints = append(c.data.Ints, 0)
+	case reflect.Bool:
+		c.nullRows = append(c.nullRows, uint32(len(c.data.Bools)))
+		c.data.Bools = append(c.data.Bools, false)
 	default:
 		return qerrors.New("Column Null", "non-nullable type: %s", c.kind)
 	}
@@ -56,6 +78,14 @@ func (c *Column) Int(i int) {
 	if c.ptr == nil {
 		c.kind = reflect.Int
 		c.ptr = &c.data.Ints
+		// add any NULL ints previously scanned
+		if c.nulls > 0 {
+			for i := 0; i < c.nulls; i++ {
+				c.nullRows = append(c.nullRows, uint32(i))
+				c.data.Ints = append(c.data.Ints, 0)
+			}
+			c.nulls = 0
+		}
 	}
 	c.data.Ints = append(c.data.Ints, i)
 }
@@ -100,10 +130,50 @@ func (c *Column) Bool(b bool) {
 	if c.ptr == nil {
 		c.kind = reflect.Bool
 		c.ptr = &c.data.Bools
+		// add any NULL bools previously scanned
+		if c.nulls > 0 {
+			for i := 0; i < c.nulls; i++ {
+				c.nullRows = append(c.nullRows, uint32(i))
+				c.data.Bools = append(c.data.Bools, false)
+			}
+			c.nulls = 0
+		}
 	}
 	c.data.Bools = append(c.data.Bools, b)
 }
 
+// Time adds a new time to the underlying data slice
+func (c *Column) Time(t time.Time) {
+	if c.ptr == nil {
+		c.kind = reflect.Struct
+		c.ptr = &c.data.Times
+		// add any NULL times previously scanned
+		if c.nulls > 0 {
+			for i := 0; i < c.nulls; i++ {
+				c.data.Times = append(c.data.Times, nil)
+			}
+			c.nulls = 0
+		}
+	}
+	c.data.Times = append(c.data.Times, &t)
+}
+
+// Decimal adds a new decimal to the underlying data slice
+func (c *Column) Decimal(d decimal.Decimal) {
+	if c.ptr == nil {
+		c.kind = reflect.Struct
+		c.ptr = &c.data.Decimals
+		// add any NULL decimals previously scanned
+		if c.nulls > 0 {
+			for i := 0; i < c.nulls; i++ {
+				c.data.Decimals = append(c.data.Decimals, nil)
+			}
+			c.nulls = 0
+		}
+	}
+	c.data.Decimals = append(c.data.Decimals, &d)
+}
+
 // Scan implements the sql.Scanner interface
 func (c *Column) Scan(t interface{}) error {
 	if c.coerce != nil {
@@ -120,6 +190,8 @@ func (c *Column) Scan(t interface{}) error {
 		c.String(string(v))
 	case float64:
 		c.Float(v)
+	case time.Time:
+		c.Time(v)
 	case nil:
 		err := c.Null()
 		if err != nil {
@@ -132,11 +204,29 @@ func (c *Column) Scan(t interface{}) error {
 	return nil
 }
 
-// Data returns the underlying data slice
+// Data returns the underlying data slice, or a
+// column if the data contains NULL values that
+// cannot be represented in the data slice.
 func (c *Column) Data() interface{} {
 	if c.ptr == nil {
 		return nil
 	}
+	if len(c.nullRows) > 0 {
+		switch c.kind {
+		case reflect.Int:
+			return icolumn.NewNullable(c.data.Ints, c.nullSet(len(c.data.Ints)))
+		case reflect.Bool:
+			return bcolumn.NewNullable(c.data.Bools, c.nullSet(len(c.data.Bools)))
+		}
+	}
 	// *[]<T> -> []<T>
 	return reflect.ValueOf(c.ptr).Elem().Interface()
 }
+
+func (c *Column) nullSet(size int) nulls.Set {
+	result := nulls.New(size)
+	for _, i := range c.nullRows {
+		result.Add(i)
+	}
+	return result
+}
@@ -3,6 +3,8 @@ package sql
 import (
 	"math"
 	"testing"
+
+	"github.com/tobgu/qframe/internal/icolumn"
 )
 
 func assertEqual(t *testing.T, expected, actual interface{}) {
@@ -66,6 +68,18 @@ func TestColumnCoercion(t *testing.T) {
 	assertEqual(t, false, data[3])
 }
 
+func TestColumnNullInt(t *testing.T) {
+	col := &Column{}
+	panicOnErr(col.Scan(nil))
+	panicOnErr(col.Scan(int64(1)))
+	panicOnErr(col.Scan(nil))
+	data := col.Data().(icolumn.Column)
+	assertEqual(t, 3, data.Len())
+	assertEqual(t, "null", data.StringAt(0, "null"))
+	assertEqual(t, "1", data.StringAt(1, "null"))
+	assertEqual(t, "null", data.StringAt(2, "null"))
+}
+
 func BenchmarkColumn(b *testing.B) {
 	col := &Column{}
 	for n := 0; n < b.N; n++ {
@@ -6,11 +6,13 @@ import (
 
 	"github.com/tobgu/qframe/internal/bcolumn"
 	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/dcolumn"
 	"github.com/tobgu/qframe/internal/ecolumn"
 	"github.com/tobgu/qframe/internal/fcolumn"
 	"github.com/tobgu/qframe/internal/icolumn"
 	"github.com/tobgu/qframe/internal/index"
 	"github.com/tobgu/qframe/internal/scolumn"
+	"github.com/tobgu/qframe/internal/tcolumn"
 	"github.com/tobgu/qframe/qerrors"
 )
 
@@ -45,11 +47,17 @@ func NewArgBuilder(col column.Column) (ArgBuilder, error) {
 	switch c := col.(type) {
 	case bcolumn.Column:
 		return func(ix index.Int, i int) interface{} {
-			return c.View(ix).ItemAt(i)
+			if v := c.View(ix); !v.IsNull(i) {
+				return v.ItemAt(i)
+			}
+			return nil
 		}, nil
 	case icolumn.Column:
 		return func(ix index.Int, i int) interface{} {
-			return c.View(ix).ItemAt(i)
+			if v := c.View(ix); !v.IsNull(i) {
+				return v.ItemAt(i)
+			}
+			return nil
 		}, nil
 	case fcolumn.Column:
 		return func(ix index.Int, i int) interface{} {
@@ -63,6 +71,21 @@ func NewArgBuilder(col column.Column) (ArgBuilder, error) {
 		return func(ix index.Int, i int) interface{} {
 			return c.View(ix).ItemAt(i)
 		}, nil
+	case dcolumn.Column:
+		return func(ix index.Int, i int) interface{} {
+			if v := c.View(ix); !v.IsNull(i) {
+				// Text is used to not lose any precision
+				return v.ItemAt(i).String()
+			}
+			return nil
+		}, nil
+	case tcolumn.Column:
+		return func(ix index.Int, i int) interface{} {
+			if v := c.View(ix); !v.IsNull(i) {
+				return v.ItemAt(i)
+			}
+			return nil
+		}, nil
 	}
 	return nil, qerrors.New("NewArgBuilder", fmt.Sprintf("bad column type: %s", reflect.TypeOf(col).Name()))
 }
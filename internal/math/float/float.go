@@ -12,3 +12,23 @@ func Fixed(num float64, precision int) float64 {
 	i := math.Pow(10, float64(precision))
 	return float64(Round(num*i)) / i
 }
+
+// Quantile returns the q quantile of the sorted values, interpolating linearly
+// between the closest values if the quantile falls between two of them.
+// The result is NaN if there are no values.
+func Quantile(sorted []float64, q float64) float64 {
+	return QuantileAt(len(sorted), func(i int) float64 { return sorted[i] }, q)
+}
+
+// QuantileAt works like Quantile for n sorted values accessed through at. It allows
+// values of other types to be used without first copying them into a float slice.
+func QuantileAt(n int, at func(i int) float64, q float64) float64 {
+	if n == 0 {
+		return math.NaN()
+	}
+
+	pos := q * float64(n-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	return at(lower) + (pos-float64(lower))*(at(upper)-at(lower))
+}
@@ -7,9 +7,9 @@ It is for example used when reading zero row CSVs without type hints.
 */
 
 import (
-	"github.com/tobgu/qframe/config/rolling"
 	"github.com/tobgu/qframe/internal/column"
 	"github.com/tobgu/qframe/internal/index"
+	qfrolling "github.com/tobgu/qframe/internal/rolling"
 	"github.com/tobgu/qframe/types"
 )
 
@@ -27,6 +27,24 @@ func (c Column) Subset(index index.Int) column.Column {
 	return c
 }
 
+// Append returns the first column in cols that is not a null column with the rest of
+// cols appended to it. If all columns are null columns this column is returned.
+func (c Column) Append(cols ...column.Column) (column.Column, error) {
+	for i, col := range cols {
+		if _, ok := col.(Column); !ok {
+			rest := make([]column.Column, 0, len(cols)-i-1)
+			for _, r := range cols[i+1:] {
+				if _, ok := r.(Column); !ok {
+					rest = append(rest, r)
+				}
+			}
+			return col.Append(rest...)
+		}
+	}
+
+	return c, nil
+}
+
 func (c Column) Equals(index index.Int, other column.Column, otherIndex index.Int) bool {
 	return false
 }
@@ -35,7 +53,7 @@ func (c Column) Comparable(reverse, equalNull, nullLast bool) column.Comparable
 	return Comparable{}
 }
 
-func (c Column) Aggregate(indices []index.Int, fn interface{}) (column.Column, error) {
+func (c Column) Aggregate(indices []index.Int, fn interface{}) (interface{}, error) {
 	return c, nil
 }
 
@@ -63,10 +81,14 @@ func (c Column) Apply2(fn interface{}, s2 column.Column, ix index.Int) (column.C
 	return c, nil
 }
 
-func (c Column) Rolling(fn interface{}, ix index.Int, config rolling.Config) (column.Column, error) {
+func (c Column) Rolling(fn interface{}, ix index.Int, windows []qfrolling.Window, padValue interface{}) (column.Column, error) {
 	return c, nil
 }
 
+func (c Column) IntervalWindows(fn interface{}, ix index.Int, position string) ([]qfrolling.Window, error) {
+	return nil, nil
+}
+
 func (c Column) FunctionType() types.FunctionType {
 	return types.FunctionTypeUndefined
 }
@@ -0,0 +1,85 @@
+/*
+Package nulls contains a bitmap used to keep track of null values in columns
+that cannot represent null using a special value of the data type itself.
+*/
+package nulls
+
+import "github.com/tobgu/qframe/internal/index"
+
+// Set holds the positions of null values in a column. The nil Set contains no
+// nulls, columns without nulls hence carry no overhead.
+type Set []uint64
+
+// New returns an empty Set that can hold positions up to size.
+func New(size int) Set {
+	return make(Set, (size+63)/64)
+}
+
+// Add adds position i to the set. Must not be called on a nil Set.
+func (s Set) Add(i uint32) {
+	s[i>>6] |= 1 << (i & 0x3F)
+}
+
+// Contains returns true if position i is in the set.
+func (s Set) Contains(i uint32) bool {
+	return s != nil && s[i>>6]&(1<<(i&0x3F)) > 0
+}
+
+// Subset returns a Set holding the nulls at the positions in ix, positions in
+// the returned Set refer to positions in ix. Nil is returned if there are no nulls.
+func (s Set) Subset(ix index.Int) Set {
+	if s == nil {
+		return nil
+	}
+
+	var result Set
+	for i, x := range ix {
+		if s.Contains(x) {
+			if result == nil {
+				result = New(len(ix))
+			}
+			result.Add(uint32(i))
+		}
+	}
+
+	return result
+}
+
+// Concat returns a Set holding the nulls of all sets placed after each other.
+// lengths hold the length of the column that each set belongs to. Nil is
+// returned if there are no nulls.
+func Concat(sets []Set, lengths []int) Set {
+	size := 0
+	hasNulls := false
+	for i, s := range sets {
+		size += lengths[i]
+		hasNulls = hasNulls || s != nil
+	}
+
+	if !hasNulls {
+		return nil
+	}
+
+	result := New(size)
+	offset := 0
+	for i, s := range sets {
+		for j := 0; j < lengths[i] && s != nil; j++ {
+			if s.Contains(uint32(j)) {
+				result.Add(uint32(offset + j))
+			}
+		}
+		offset += lengths[i]
+	}
+
+	return result
+}
+
+// ByteSize returns the number of bytes used by the set, zero for the nil Set.
+func (s Set) ByteSize() int {
+	if s == nil {
+		return 0
+	}
+
+	// Slice header + data
+	return 3*8 + 8*cap(s)
+}
@@ -71,6 +71,15 @@ func GenerateQFrame() (*bytes.Buffer, error) {
 		view("Bool", "bcolumn"),
 		view("String", "scolumn"),
 		view("Enum", "ecolumn"),
+		view("Time", "tcolumn"),
+		view("Decimal", "dcolumn"),
+		view("Int8", "i8column"),
+		view("Int16", "i16column"),
+		view("Int32", "i32column"),
+		view("Uint8", "u8column"),
+		view("Uint16", "u16column"),
+		view("Uint32", "u32column"),
+		view("Float32", "f32column"),
 	}, []string{
 		"github.com/tobgu/qframe/qerrors",
 		"github.com/tobgu/qframe/internal/icolumn",
@@ -78,5 +87,14 @@ func GenerateQFrame() (*bytes.Buffer, error) {
 		"github.com/tobgu/qframe/internal/bcolumn",
 		"github.com/tobgu/qframe/internal/scolumn",
 		"github.com/tobgu/qframe/internal/ecolumn",
+		"github.com/tobgu/qframe/internal/tcolumn",
+		"github.com/tobgu/qframe/internal/dcolumn",
+		"github.com/tobgu/qframe/internal/i8column",
+		"github.com/tobgu/qframe/internal/i16column",
+		"github.com/tobgu/qframe/internal/i32column",
+		"github.com/tobgu/qframe/internal/u8column",
+		"github.com/tobgu/qframe/internal/u16column",
+		"github.com/tobgu/qframe/internal/u32column",
+		"github.com/tobgu/qframe/internal/f32column",
 	})
 }
@@ -0,0 +1,71 @@
+package rolling
+
+/*
+Package rolling contains the window calculations used by rolling operations.
+
+Windows are expressed as positions into an index rather than as row numbers. That way the
+same calculations can be used for rolling over a full QFrame as well as over groups of rows.
+*/
+
+// Window describes the positions [Start, End) of an index that make up the window
+// used to calculate the value at one position.
+type Window struct {
+	Start int
+	End   int
+
+	// Complete is false if the window holds fewer positions than requested.
+	Complete bool
+}
+
+// FixedWindows returns one window of size positions for each of the length positions of an index.
+// Position (start/center/end) tells where in the window the position that the window belongs to is.
+// Windows that would extend outside of the index are truncated and marked as incomplete.
+func FixedWindows(length, size int, position string) []Window {
+	offset := size / 2
+	switch position {
+	case "start":
+		offset = 0
+	case "end":
+		offset = size - 1
+	}
+
+	result := make([]Window, length)
+	for i := range result {
+		w := Window{Start: i - offset, End: i - offset + size, Complete: true}
+		if w.Start < 0 {
+			w.Start, w.Complete = 0, false
+		}
+
+		if w.End > length {
+			w.End, w.Complete = length, false
+		}
+
+		result[i] = w
+	}
+
+	return result
+}
+
+// IntervalWindows returns one window for each of the length positions of an index. The windows
+// cover all consecutive positions that are within the interval according to inInterval, starting
+// from (position = start) or ending at (position = end) the position that the window belongs to.
+// inInterval(i, j) should report if position j is within the interval starting at position i.
+func IntervalWindows(length int, position string, inInterval func(i, j int) bool) []Window {
+	result := make([]Window, length)
+	for i := range result {
+		w := Window{Start: i, End: i + 1, Complete: true}
+		if position == "end" {
+			for w.Start > 0 && inInterval(w.Start-1, i) {
+				w.Start--
+			}
+		} else {
+			for w.End < length && inInterval(i, w.End) {
+				w.End++
+			}
+		}
+
+		result[i] = w
+	}
+
+	return result
+}
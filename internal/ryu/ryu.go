@@ -71,6 +71,26 @@ func AppendFloat32(b []byte, f float32) []byte {
 	return d.append(b, neg)
 }
 
+// AppendFloat32f appends the string form of the 32-bit floating point number f to b
+// and returns the extended buffer.
+// It behaves like strconv.AppendFloat(b, float64(f), 'f', -1, 32).
+func AppendFloat32f(b []byte, f float32) []byte {
+	u := math.Float32bits(f)
+	neg := u>>(mantBits32+expBits32) != 0
+	mant := u & (uint32(1)<<mantBits32 - 1)
+	exp := (u >> mantBits32) & (uint32(1)<<expBits32 - 1)
+
+	if exp == uint32(1)<<expBits32-1 || (exp == 0 && mant == 0) {
+		return appendSpecialf(b, neg, exp == 0, mant == 0)
+	}
+
+	d, ok := float32ToDecimalExactInt(mant, exp)
+	if !ok {
+		d = float32ToDecimal(mant, exp)
+	}
+	return dec64{m: uint64(d.m), e: d.e}.appendF(b, neg)
+}
+
 // FormatFloat64 converts a 64-bit floating point number f to a string.
 // It behaves like strconv.FormatFloat(f, 'e', -1, 64).
 func FormatFloat64(f float64) string {
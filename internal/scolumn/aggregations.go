@@ -0,0 +1,98 @@
+package scolumn
+
+import (
+	"github.com/tobgu/qframe/aggregation"
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/qerrors"
+)
+
+// DefaultJoinSeparator is the separator used by the join aggregation unless one is given, join(sep).
+const DefaultJoinSeparator = ","
+
+var aggregations = map[string]interface{}{
+	"min":            min,
+	"max":            max,
+	"first":          first,
+	"last":           last,
+	"mode":           mode,
+	"count_distinct": countDistinct,
+	"join":           aggregation.StrJoin(DefaultJoinSeparator),
+}
+
+// builtInAggregation returns the built in aggregation function identified by name, either
+// a func([]*string) *string or a func([]*string) int. The separator of the join aggregation
+// may be given as a parameter, eg. join(;).
+func builtInAggregation(name string) (interface{}, error) {
+	if fn, ok := aggregations[name]; ok {
+		return fn, nil
+	}
+
+	if fnName, sep, ok := column.ParseParametric(name); ok && fnName == "join" {
+		return aggregation.StrJoin(sep), nil
+	}
+
+	return nil, qerrors.New("string aggregate", "aggregation function %s is not defined for string column", name)
+}
+
+// Null is ignored by min and max, the result is null if all values are null.
+func min(values []*string) *string {
+	var result *string
+	for _, v := range values {
+		if v != nil && (result == nil || *v < *result) {
+			result = v
+		}
+	}
+	return result
+}
+
+func max(values []*string) *string {
+	var result *string
+	for _, v := range values {
+		if v != nil && (result == nil || *v > *result) {
+			result = v
+		}
+	}
+	return result
+}
+
+func first(values []*string) *string {
+	return values[0]
+}
+
+func last(values []*string) *string {
+	return values[len(values)-1]
+}
+
+// mode returns the most frequent non null value. If several values are equally
+// frequent the smallest of them is returned. The result is null if all values are null.
+func mode(values []*string) *string {
+	counts := make(map[string]int, len(values))
+	var result *string
+	maxCount := 0
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+
+		count := counts[*v] + 1
+		counts[*v] = count
+		if count > maxCount || count == maxCount && *v < *result {
+			result, maxCount = v, count
+		}
+	}
+	return result
+}
+
+// countDistinct returns the number of distinct values, null counts as one value.
+func countDistinct(values []*string) int {
+	seen := make(map[string]struct{}, len(values))
+	hasNull := 0
+	for _, v := range values {
+		if v == nil {
+			hasNull = 1
+		} else {
+			seen[*v] = struct{}{}
+		}
+	}
+	return len(seen) + hasNull
+}
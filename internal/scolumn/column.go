@@ -3,10 +3,11 @@ package scolumn
 import (
 	"bytes"
 	"fmt"
-	"github.com/tobgu/qframe/config/rolling"
 	"github.com/tobgu/qframe/internal/column"
 	"github.com/tobgu/qframe/internal/hash"
+	"github.com/tobgu/qframe/internal/icolumn"
 	"github.com/tobgu/qframe/internal/index"
+	qfrolling "github.com/tobgu/qframe/internal/rolling"
 	qfstrings "github.com/tobgu/qframe/internal/strings"
 	"github.com/tobgu/qframe/qerrors"
 	"github.com/tobgu/qframe/types"
@@ -326,6 +327,35 @@ func (c Column) Subset(index index.Int) column.Column {
 	return c.subset(index)
 }
 
+// Append returns a new column holding the strings of this column followed by the strings
+// of all columns in cols. All columns must be string columns.
+func (c Column) Append(cols ...column.Column) (column.Column, error) {
+	all := make([]Column, 0, len(cols)+1)
+	all = append(all, c)
+	pointerCount, dataSize := len(c.pointers), len(c.data)
+	for _, col := range cols {
+		sCol, ok := col.(Column)
+		if !ok {
+			return nil, qerrors.New("string.Append", "invalid column type: %s", col.DataType())
+		}
+		all = append(all, sCol)
+		pointerCount += len(sCol.pointers)
+		dataSize += len(sCol.data)
+	}
+
+	pointers := make([]qfstrings.Pointer, 0, pointerCount)
+	data := make([]byte, 0, dataSize)
+	for _, sCol := range all {
+		offset := len(data)
+		data = append(data, sCol.data...)
+		for _, p := range sCol.pointers {
+			pointers = append(pointers, qfstrings.NewPointer(offset+p.Offset(), p.Len(), p.IsNull()))
+		}
+	}
+
+	return NewBytes(pointers, data), nil
+}
+
 func (c Column) Comparable(reverse, equalNull, nullLast bool) column.Comparable {
 	result := Comparable{column: c, ltValue: column.LessThan, gtValue: column.GreaterThan, nullLtValue: column.LessThan, nullGtValue: column.GreaterThan, equalNullValue: column.NotEqual}
 	if reverse {
@@ -348,17 +378,45 @@ func (c Column) String() string {
 	return fmt.Sprintf("%v", c.data)
 }
 
-func (c Column) Aggregate(indices []index.Int, fn interface{}) (column.Column, error) {
+func (c Column) Aggregate(indices []index.Int, fn interface{}) (interface{}, error) {
+	if name, ok := fn.(string); ok {
+		var err error
+		if fn, err = builtInAggregation(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return AggregateStrings(indices, fn, c.stringSlice)
+}
+
+// AggregateStrings applies fn to the strings of each group using stringSlice to get the strings for an index.
+// It is shared between string and enum columns. The result is a string column or a slice of the result type.
+func AggregateStrings(indices []index.Int, fn interface{}, stringSlice func(index.Int) []*string) (interface{}, error) {
 	switch t := fn.(type) {
-	case string:
-		// There are currently no built in aggregations for strings
-		return nil, qerrors.New("string aggregate", "aggregation function %c is not defined for string column", fn)
 	case func([]*string) *string:
 		data := make([]*string, 0, len(indices))
 		for _, ix := range indices {
-			data = append(data, t(c.stringSlice(ix)))
+			data = append(data, t(stringSlice(ix)))
 		}
 		return New(data), nil
+	case func([]*string) int:
+		data := make([]int, 0, len(indices))
+		for _, ix := range indices {
+			data = append(data, t(stringSlice(ix)))
+		}
+		return data, nil
+	case func([]*string) float64:
+		data := make([]float64, 0, len(indices))
+		for _, ix := range indices {
+			data = append(data, t(stringSlice(ix)))
+		}
+		return data, nil
+	case func([]*string) bool:
+		data := make([]bool, 0, len(indices))
+		for _, ix := range indices {
+			data = append(data, t(stringSlice(ix)))
+		}
+		return data, nil
 	default:
 		return nil, qerrors.New("string aggregate", "invalid aggregation function type: %v", t)
 	}
@@ -432,8 +490,102 @@ func (c Column) View(ix index.Int) View {
 	return View{column: c, index: ix}
 }
 
-func (c Column) Rolling(fn interface{}, ix index.Int, config rolling.Config) (column.Column, error) {
-	return c, nil
+// stringPadValue converts a pad value given as string or *string to a *string.
+func stringPadValue(padValue interface{}) (*string, error) {
+	switch t := padValue.(type) {
+	case string:
+		return &t, nil
+	case *string:
+		return t, nil
+	default:
+		return nil, qerrors.New("string.Rolling", "invalid pad value type: %v", padValue)
+	}
+}
+
+// RollingStrings applies fn to each window. fn is either a func([]*string) *string or a func([]*string) int
+// applied to the strings of the window using stringSlice, or a func(index.Int) *string or func(index.Int) int
+// applied to the positions of the window. It is shared between string and enum columns, the result is a
+// string or int column of length size depending on the result type of fn.
+func RollingStrings(fn interface{}, stringSlice func(index.Int) []*string, size int, ix index.Int, windows []qfrolling.Window, padValue interface{}) (column.Column, error) {
+	switch t := fn.(type) {
+	case func([]*string) *string:
+		return rollingStrings(func(wix index.Int) *string { return t(stringSlice(wix)) }, size, ix, windows, padValue)
+	case func(index.Int) *string:
+		return rollingStrings(t, size, ix, windows, padValue)
+	case func([]*string) int:
+		return rollingInts(func(wix index.Int) int { return t(stringSlice(wix)) }, size, ix, windows, padValue)
+	case func(index.Int) int:
+		return rollingInts(t, size, ix, windows, padValue)
+	default:
+		return nil, qerrors.New("string.Rolling", "invalid rolling function type: %v", fn)
+	}
+}
+
+func rollingStrings(fn func(index.Int) *string, size int, ix index.Int, windows []qfrolling.Window, padValue interface{}) (column.Column, error) {
+	var pad *string
+	if padValue != nil {
+		var err error
+		if pad, err = stringPadValue(padValue); err != nil {
+			return nil, err
+		}
+	}
+
+	data := make([]*string, size)
+	for i, w := range windows {
+		if !w.Complete && padValue != nil {
+			data[ix[i]] = pad
+			continue
+		}
+
+		data[ix[i]] = fn(ix[w.Start:w.End])
+	}
+
+	return New(data), nil
+}
+
+func rollingInts(fn func(index.Int) int, size int, ix index.Int, windows []qfrolling.Window, padValue interface{}) (column.Column, error) {
+	var pad int
+	if padValue != nil {
+		var ok bool
+		if pad, ok = padValue.(int); !ok {
+			return nil, qerrors.New("string.Rolling", "invalid pad value type: %v", padValue)
+		}
+	}
+
+	// Rows not part of any window are null
+	data := make([]*int, size)
+	for i, w := range windows {
+		x := pad
+		if w.Complete || padValue == nil {
+			x = fn(ix[w.Start:w.End])
+		}
+		data[ix[i]] = &x
+	}
+
+	return icolumn.NewPtrs(data), nil
+}
+
+// Rolling applies fn to the data of each window. fn may also be the name of a built in aggregation.
+func (c Column) Rolling(fn interface{}, ix index.Int, windows []qfrolling.Window, padValue interface{}) (column.Column, error) {
+	if name, ok := fn.(string); ok {
+		var err error
+		if fn, err = builtInAggregation(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return RollingStrings(fn, c.stringSlice, c.Len(), ix, windows, padValue)
+}
+
+func (c Column) IntervalWindows(fn interface{}, ix index.Int, position string) ([]qfrolling.Window, error) {
+	t, ok := fn.(func(*string, *string) bool)
+	if !ok {
+		return nil, qerrors.New("string.IntervalWindows", "invalid interval function type: %v", fn)
+	}
+
+	return qfrolling.IntervalWindows(len(ix), position, func(i, j int) bool {
+		return t(stringToPtr(c.stringAt(ix[i])), stringToPtr(c.stringAt(ix[j])))
+	}), nil
 }
 
 func (c Column) FunctionType() types.FunctionType {
@@ -73,5 +73,9 @@ func GenerateDoc() (*bytes.Buffer, error) {
 	return template.GenerateDocs(
 		"scolumn",
 		maps.StringKeys(filterFuncs0, filterFuncs1, filterFuncs2, multiInputFilterFuncs),
-		maps.StringKeys())
+		aggregationNames())
+}
+
+func aggregationNames() []string {
+	return append(maps.StringKeys(aggregations), "join(sep)")
 }
@@ -23,6 +23,34 @@ func (s Sorter) Sort() {
 	quickSort(s, 0, n, maxDepth(n))
 }
 
+// TopK partially sorts the index so that it starts with the k smallest elements, in order.
+// The order of the remaining elements is undefined.
+func (s Sorter) TopK(k int) {
+	n := s.Len()
+	if k >= n {
+		s.Sort()
+		return
+	}
+
+	if k <= 0 {
+		return
+	}
+
+	// Keep the k smallest elements seen so far in a heap with the greatest element at the top.
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDown(s, i, k, 0)
+	}
+
+	for i := k; i < n; i++ {
+		if s.Less(i, 0) {
+			s.Swap(0, i)
+			siftDown(s, 0, k, 0)
+		}
+	}
+
+	heapSort(s, 0, k)
+}
+
 func (s Sorter) Len() int {
 	return len(s.index)
 }
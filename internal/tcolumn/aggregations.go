@@ -0,0 +1,51 @@
+package tcolumn
+
+import "time"
+
+// NB! Null values are not part of the slices passed to the built in aggregations.
+// The aggregations returning a time are never called with an empty slice.
+
+var aggregations = map[string]interface{}{
+	"min":            min,
+	"max":            max,
+	"first":          first,
+	"last":           last,
+	"count_distinct": countDistinct,
+}
+
+func min(values []time.Time) time.Time {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v.Before(result) {
+			result = v
+		}
+	}
+	return result
+}
+
+func max(values []time.Time) time.Time {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v.After(result) {
+			result = v
+		}
+	}
+	return result
+}
+
+func first(values []time.Time) time.Time {
+	return values[0]
+}
+
+func last(values []time.Time) time.Time {
+	return values[len(values)-1]
+}
+
+// countDistinct returns the number of distinct instants, nulls are not counted.
+func countDistinct(values []time.Time) int {
+	seen := make(map[int64]struct{}, len(values))
+	for _, v := range values {
+		seen[v.UnixNano()] = struct{}{}
+	}
+	return len(seen)
+}
@@ -0,0 +1,628 @@
+package tcolumn
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"time"
+	"unsafe"
+
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/hash"
+	"github.com/tobgu/qframe/internal/index"
+	"github.com/tobgu/qframe/internal/nulls"
+	qfrolling "github.com/tobgu/qframe/internal/rolling"
+	"github.com/tobgu/qframe/qerrors"
+	"github.com/tobgu/qframe/types"
+)
+
+// Column holds time values as nanoseconds since the Unix epoch. All values share the
+// location of the column which is used when values are accessed or formatted.
+type Column struct {
+	data []int64
+
+	// nulls holds the positions of null values, the data at these positions is always zero.
+	nulls nulls.Set
+
+	// loc is the location of the column, nil means UTC.
+	loc *time.Location
+}
+
+// New creates a new column from times. The location of the column is taken from the first time.
+func New(times []time.Time) Column {
+	data := make([]int64, len(times))
+	for i, t := range times {
+		data[i] = t.UnixNano()
+	}
+
+	var loc *time.Location
+	if len(times) > 0 {
+		loc = times[0].Location()
+	}
+
+	return Column{data: data, loc: loc}
+}
+
+// NewPtrs creates a new column from pointers to times, nil pointers are null. The location
+// of the column is taken from the first non null time.
+func NewPtrs(times []*time.Time) Column {
+	data := make([]int64, len(times))
+	var nullSet nulls.Set
+	var loc *time.Location
+	for i, t := range times {
+		if t == nil {
+			if nullSet == nil {
+				nullSet = nulls.New(len(times))
+			}
+			nullSet.Add(uint32(i))
+			continue
+		}
+
+		if loc == nil {
+			loc = t.Location()
+		}
+		data[i] = t.UnixNano()
+	}
+
+	return Column{data: data, nulls: nullSet, loc: loc}
+}
+
+// NewNullable creates a new column from nanosecond instants in location loc where the
+// positions in nullSet are null. nullSet may be nil if there are no nulls.
+func NewNullable(data []int64, nullSet nulls.Set, loc *time.Location) Column {
+	for i := range data {
+		if nullSet.Contains(uint32(i)) {
+			data[i] = 0
+		}
+	}
+
+	return Column{data: data, nulls: nullSet, loc: loc}
+}
+
+// NewNull creates a new column with count null values.
+func NewNull(count int) Column {
+	nullSet := nulls.New(count)
+	for i := 0; i < count; i++ {
+		nullSet.Add(uint32(i))
+	}
+
+	return Column{data: make([]int64, count), nulls: nullSet}
+}
+
+// NewConst creates a new column with count copies of val.
+func NewConst(val time.Time, count int) Column {
+	data := make([]int64, count)
+	nanos := val.UnixNano()
+	for i := range data {
+		data[i] = nanos
+	}
+
+	return Column{data: data, loc: val.Location()}
+}
+
+func (c Column) location() *time.Location {
+	if c.loc == nil {
+		return time.UTC
+	}
+	return c.loc
+}
+
+func (c Column) timeAt(i uint32) time.Time {
+	return time.Unix(0, c.data[i]).In(c.location())
+}
+
+func (c Column) fnName(name string) string {
+	return fmt.Sprintf("%s.%s", c.DataType(), name)
+}
+
+func (c Column) DataType() types.DataType {
+	return types.Time
+}
+
+func (c Column) FunctionType() types.FunctionType {
+	return types.FunctionTypeTime
+}
+
+func (c Column) StringAt(i uint32, naRep string) string {
+	if c.nulls.Contains(i) {
+		return naRep
+	}
+
+	return c.timeAt(i).Format(time.RFC3339Nano)
+}
+
+func (c Column) AppendByteStringAt(buf []byte, i uint32) []byte {
+	if c.nulls.Contains(i) {
+		return append(buf, "null"...)
+	}
+
+	buf = append(buf, '"')
+	buf = c.timeAt(i).AppendFormat(buf, time.RFC3339Nano)
+	return append(buf, '"')
+}
+
+func (c Column) ByteSize() int {
+	// Slice header + data + nulls + location pointer
+	return 2*8 + 8*cap(c.data) + c.nulls.ByteSize() + 8
+}
+
+func (c Column) Len() int {
+	return len(c.data)
+}
+
+func (c Column) String() string {
+	return fmt.Sprintf("%v", c.data)
+}
+
+// Equals compares the instants and null status of the columns, the locations of the columns are not considered.
+func (c Column) Equals(index index.Int, other column.Column, otherIndex index.Int) bool {
+	otherT, ok := other.(Column)
+	if !ok {
+		return false
+	}
+
+	for ix, x := range index {
+		y := otherIndex[ix]
+		if c.data[x] != otherT.data[y] || c.nulls.Contains(x) != otherT.nulls.Contains(y) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c Column) subset(index index.Int) Column {
+	data := make([]int64, len(index))
+	for i, ix := range index {
+		data[i] = c.data[ix]
+	}
+
+	return Column{data: data, nulls: c.nulls.Subset(index), loc: c.loc}
+}
+
+func (c Column) Subset(index index.Int) column.Column {
+	return c.subset(index)
+}
+
+// Append returns a new column holding the data of this column followed by the data of all columns
+// in cols. All columns must be time columns. The resulting column has the location of this column.
+func (c Column) Append(cols ...column.Column) (column.Column, error) {
+	size := len(c.data)
+	for _, col := range cols {
+		if _, ok := col.(Column); !ok {
+			return nil, qerrors.New(c.fnName("Append"), "invalid column type: %s", col.DataType())
+		}
+		size += col.Len()
+	}
+
+	data := make([]int64, 0, size)
+	data = append(data, c.data...)
+	nullSets, lengths := []nulls.Set{c.nulls}, []int{len(c.data)}
+	loc := c.loc
+	for _, col := range cols {
+		tCol := col.(Column)
+		data = append(data, tCol.data...)
+		nullSets, lengths = append(nullSets, tCol.nulls), append(lengths, tCol.Len())
+		if loc == nil && !tCol.allNull() {
+			loc = tCol.loc
+		}
+	}
+
+	return Column{data: data, nulls: nulls.Concat(nullSets, lengths), loc: loc}, nil
+}
+
+// allNull returns true if the column does not contain any non null values. Such columns
+// are typically created to fill in nulls and do not carry any location.
+func (c Column) allNull() bool {
+	for i := range c.data {
+		if !c.nulls.Contains(uint32(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Column) Comparable(reverse, equalNull, nullLast bool) column.Comparable {
+	result := Comparable{data: c.data, nulls: c.nulls, ltValue: column.LessThan, gtValue: column.GreaterThan, nullLtValue: column.LessThan, nullGtValue: column.GreaterThan, equalNullValue: column.NotEqual}
+	if reverse {
+		result.ltValue, result.nullLtValue, result.gtValue, result.nullGtValue =
+			result.gtValue, result.nullGtValue, result.ltValue, result.nullLtValue
+	}
+
+	if nullLast {
+		result.nullLtValue, result.nullGtValue = result.nullGtValue, result.nullLtValue
+	}
+
+	if equalNull {
+		result.equalNullValue = column.Equal
+	}
+
+	return result
+}
+
+func (c Comparable) Compare(i, j uint32) column.CompareResult {
+	if c.nulls != nil {
+		xNull, yNull := c.nulls.Contains(i), c.nulls.Contains(j)
+		if xNull || yNull {
+			if !xNull {
+				return c.nullGtValue
+			}
+
+			if !yNull {
+				return c.nullLtValue
+			}
+
+			return c.equalNullValue
+		}
+	}
+
+	x, y := c.data[i], c.data[j]
+	if x < y {
+		return c.ltValue
+	}
+
+	if x > y {
+		return c.gtValue
+	}
+
+	return column.Equal
+}
+
+func (c Comparable) Hash(i uint32, seed uint64) uint64 {
+	if c.nulls.Contains(i) {
+		if c.equalNullValue == column.NotEqual {
+			// Use a random value here to avoid hash collisions when
+			// we don't consider null to equal null.
+			return rand.Uint64()
+		}
+
+		b := [1]byte{0}
+		return hash.HashBytes(b[:], seed)
+	}
+
+	x := &c.data[i]
+	b := (*[8]byte)(unsafe.Pointer(x))[:]
+	return hash.HashBytes(b, seed)
+}
+
+// timeComp converts comparatee to nanoseconds if it is a time or a string in RFC3339 format.
+func timeComp(comparatee interface{}) (int64, bool, error) {
+	switch t := comparatee.(type) {
+	case time.Time:
+		return t.UnixNano(), true, nil
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return 0, false, qerrors.Propagate("filter time", err)
+		}
+		return parsed.UnixNano(), true, nil
+	default:
+		return 0, false, nil
+	}
+}
+
+func newTimeSet(input interface{}) (timeSet, bool) {
+	switch t := input.(type) {
+	case []time.Time:
+		result := make(timeSet, len(t))
+		for _, v := range t {
+			result[v.UnixNano()] = struct{}{}
+		}
+		return result, true
+	case []interface{}:
+		result := make(timeSet, len(t))
+		for _, v := range t {
+			nanos, ok, err := timeComp(v)
+			if !ok || err != nil {
+				return nil, false
+			}
+			result[nanos] = struct{}{}
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+type timeSet map[int64]struct{}
+
+func (ts timeSet) Contains(x int64) bool {
+	_, ok := ts[x]
+	return ok
+}
+
+func (c Column) filterBuiltIn(index index.Int, comparator string, comparatee interface{}, bIndex index.Bool) error {
+	comp, ok, err := timeComp(comparatee)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		filterFn, ok := filterFuncs1[comparator]
+		if !ok {
+			return qerrors.New("filter time", "unknown filter operator %v for single value argument", comparator)
+		}
+		filterFn(index, c, comp, bIndex)
+	} else if set, ok := newTimeSet(comparatee); ok {
+		filterFn, ok := multiInputFilterFuncs[comparator]
+		if !ok {
+			return qerrors.New("filter time", "unknown filter operator %v for multi value argument", comparator)
+		}
+		filterFn(index, c, set, bIndex)
+	} else if columnC, ok := comparatee.(Column); ok {
+		filterFn, ok := filterFuncs2[comparator]
+		if !ok {
+			return qerrors.New("filter time", "unknown filter operator %v for column - column comparison", comparator)
+		}
+		filterFn(index, c, columnC, bIndex)
+	} else if comparatee == nil {
+		filterFn, ok := filterFuncs0[comparator]
+		if !ok {
+			return qerrors.New("filter time", "unknown filter operator %v for zero argument", comparator)
+		}
+		filterFn(index, c, bIndex)
+	} else {
+		return qerrors.New("filter time", "invalid comparison value type %v", reflect.TypeOf(comparatee))
+	}
+
+	return nil
+}
+
+// Null values never match custom filter functions.
+func (c Column) filterCustom1(index index.Int, fn func(time.Time) bool, bIndex index.Bool) {
+	for i, x := range bIndex {
+		if !x && !c.nulls.Contains(index[i]) {
+			bIndex[i] = fn(c.timeAt(index[i]))
+		}
+	}
+}
+
+func (c Column) filterCustom2(index index.Int, fn func(time.Time, time.Time) bool, comparatee interface{}, bIndex index.Bool) error {
+	otherC, ok := comparatee.(Column)
+	if !ok {
+		return qerrors.New("filter time", "expected comparatee to be time column, was %v", reflect.TypeOf(comparatee))
+	}
+
+	for i, x := range bIndex {
+		if !x && !c.nulls.Contains(index[i]) && !otherC.nulls.Contains(index[i]) {
+			bIndex[i] = fn(c.timeAt(index[i]), otherC.timeAt(index[i]))
+		}
+	}
+
+	return nil
+}
+
+func (c Column) Filter(index index.Int, comparator interface{}, comparatee interface{}, bIndex index.Bool) error {
+	var err error
+	switch t := comparator.(type) {
+	case string:
+		err = c.filterBuiltIn(index, t, comparatee, bIndex)
+	case func(time.Time) bool:
+		c.filterCustom1(index, t, bIndex)
+	case func(time.Time, time.Time) bool:
+		err = c.filterCustom2(index, t, comparatee, bIndex)
+	default:
+		err = qerrors.New("filter time", "invalid filter type %v", reflect.TypeOf(comparator))
+	}
+	return err
+}
+
+// Apply single argument function. The result may be a column of a different type than the
+// current column. Null values are passed to fn as the zero time.
+func (c Column) Apply1(fn interface{}, ix index.Int) (interface{}, error) {
+	switch t := fn.(type) {
+	case func(time.Time) int:
+		result := make([]int, len(c.data))
+		for _, i := range ix {
+			result[i] = t(c.timeAt(i))
+		}
+		return result, nil
+	case func(time.Time) float64:
+		result := make([]float64, len(c.data))
+		for _, i := range ix {
+			result[i] = t(c.timeAt(i))
+		}
+		return result, nil
+	case func(time.Time) bool:
+		result := make([]bool, len(c.data))
+		for _, i := range ix {
+			result[i] = t(c.timeAt(i))
+		}
+		return result, nil
+	case func(time.Time) *string:
+		result := make([]*string, len(c.data))
+		for _, i := range ix {
+			result[i] = t(c.timeAt(i))
+		}
+		return result, nil
+	case func(time.Time) time.Time:
+		result := make([]int64, len(c.data))
+		var loc *time.Location
+		for _, i := range ix {
+			r := t(c.timeAt(i))
+			if loc == nil {
+				loc = r.Location()
+			}
+			result[i] = r.UnixNano()
+		}
+		return Column{data: result, loc: loc}, nil
+	default:
+		return nil, qerrors.New(c.fnName("Apply1"), "cannot apply type %#v to column", fn)
+	}
+}
+
+// Apply double argument function to two columns. Both columns must be time columns.
+// The resulting column will have the location of this column.
+func (c Column) Apply2(fn interface{}, s2 column.Column, ix index.Int) (column.Column, error) {
+	ss2, ok := s2.(Column)
+	if !ok {
+		return nil, qerrors.New(c.fnName("Apply2"), "invalid column type: %s", s2.DataType())
+	}
+
+	t, ok := fn.(func(time.Time, time.Time) time.Time)
+	if !ok {
+		return nil, qerrors.New(c.fnName("Apply2"), "invalid function type: %#v", fn)
+	}
+
+	result := make([]int64, len(c.data))
+	for _, i := range ix {
+		result[i] = t(c.timeAt(i), ss2.timeAt(i)).UnixNano()
+	}
+
+	return Column{data: result, loc: c.loc}, nil
+}
+
+// timesWithBuf returns the non null times at the positions in index, using buf for storage.
+func (c Column) timesWithBuf(index index.Int, buf *[]time.Time) []time.Time {
+	if cap(*buf) < len(index) {
+		*buf = make([]time.Time, 0, len(index))
+	}
+
+	result := (*buf)[:0]
+	for _, ix := range index {
+		if !c.nulls.Contains(ix) {
+			result = append(result, c.timeAt(ix))
+		}
+	}
+
+	*buf = result
+	return result
+}
+
+// Aggregate applies fn to the non null times of each group in indices. The result is either a Column or,
+// if the result type of fn is not time, a slice of the result type.
+func (c Column) Aggregate(indices []index.Int, fn interface{}) (interface{}, error) {
+	if name, ok := fn.(string); ok {
+		var ok bool
+		if fn, ok = aggregations[name]; !ok {
+			return nil, qerrors.New(c.fnName("Aggregate"), "aggregation function %s is not defined for column", name)
+		}
+	}
+
+	var buf []time.Time
+	switch t := fn.(type) {
+	case func([]time.Time) time.Time:
+		var resultNulls nulls.Set
+		data := make([]int64, 0, len(indices))
+		for i, ix := range indices {
+			times := c.timesWithBuf(ix, &buf)
+			if len(times) == 0 {
+				// Only nulls in group
+				if resultNulls == nil {
+					resultNulls = nulls.New(len(indices))
+				}
+				resultNulls.Add(uint32(i))
+				data = append(data, 0)
+				continue
+			}
+
+			data = append(data, t(times).UnixNano())
+		}
+		return Column{data: data, nulls: resultNulls, loc: c.loc}, nil
+	case func([]time.Time) int:
+		data := make([]int, 0, len(indices))
+		for _, ix := range indices {
+			data = append(data, t(c.timesWithBuf(ix, &buf)))
+		}
+		return data, nil
+	case func([]time.Time) float64:
+		data := make([]float64, 0, len(indices))
+		for _, ix := range indices {
+			data = append(data, t(c.timesWithBuf(ix, &buf)))
+		}
+		return data, nil
+	case func([]time.Time) bool:
+		data := make([]bool, 0, len(indices))
+		for _, ix := range indices {
+			data = append(data, t(c.timesWithBuf(ix, &buf)))
+		}
+		return data, nil
+	case func([]time.Time) *string:
+		data := make([]*string, 0, len(indices))
+		for _, ix := range indices {
+			data = append(data, t(c.timesWithBuf(ix, &buf)))
+		}
+		return data, nil
+	default:
+		return nil, qerrors.New(c.fnName("Aggregate"), "invalid aggregation function type: %v", t)
+	}
+}
+
+// Rolling applies fn to the non null times of each window. The result for window i is written to position ix[i],
+// or padValue if the window is incomplete and padValue has been set.
+func (c Column) Rolling(fn interface{}, ix index.Int, windows []qfrolling.Window, padValue interface{}) (column.Column, error) {
+	if name, ok := fn.(string); ok {
+		var ok bool
+		if fn, ok = aggregations[name]; !ok {
+			return nil, qerrors.New(c.fnName("Rolling"), "aggregation function %s is not defined for column", name)
+		}
+
+		if _, ok := fn.(func([]time.Time) time.Time); !ok {
+			return nil, qerrors.New(c.fnName("Rolling"), "aggregation function %s has a different result type than the column", name)
+		}
+	}
+
+	actualFn, ok := fn.(func([]time.Time) time.Time)
+	if !ok {
+		return nil, qerrors.New(c.fnName("Rolling"), "invalid rolling function type: %v", fn)
+	}
+
+	var pad int64
+	if padValue != nil {
+		padTime, ok := padValue.(time.Time)
+		if !ok {
+			return nil, qerrors.New(c.fnName("Rolling"), "invalid pad value type: %v", padValue)
+		}
+		pad = padTime.UnixNano()
+	}
+
+	data := make([]int64, len(c.data))
+	var resultNulls nulls.Set
+	var buf []time.Time
+	for i, w := range windows {
+		if !w.Complete && padValue != nil {
+			data[ix[i]] = pad
+			continue
+		}
+
+		times := c.timesWithBuf(ix[w.Start:w.End], &buf)
+		if len(times) == 0 {
+			// Only nulls in window
+			if resultNulls == nil {
+				resultNulls = nulls.New(len(c.data))
+			}
+			resultNulls.Add(ix[i])
+			continue
+		}
+
+		data[ix[i]] = actualFn(times).UnixNano()
+	}
+
+	return Column{data: data, nulls: resultNulls, loc: c.loc}, nil
+}
+
+// IntervalWindows returns the windows given by the interval function fn for the positions in ix.
+func (c Column) IntervalWindows(fn interface{}, ix index.Int, position string) ([]qfrolling.Window, error) {
+	t, ok := fn.(func(time.Time, time.Time) bool)
+	if !ok {
+		return nil, qerrors.New(c.fnName("IntervalWindows"), "invalid interval function type: %v", fn)
+	}
+
+	return qfrolling.IntervalWindows(len(ix), position, func(i, j int) bool {
+		return t(c.timeAt(ix[i]), c.timeAt(ix[j]))
+	}), nil
+}
+
+func (c Column) View(ix index.Int) View {
+	return View{column: c, index: ix}
+}
+
+type Comparable struct {
+	data           []int64
+	nulls          nulls.Set
+	ltValue        column.CompareResult
+	nullLtValue    column.CompareResult
+	gtValue        column.CompareResult
+	nullGtValue    column.CompareResult
+	equalNullValue column.CompareResult
+}
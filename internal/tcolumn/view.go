@@ -0,0 +1,41 @@
+package tcolumn
+
+import (
+	"time"
+
+	"github.com/tobgu/qframe/internal/index"
+)
+
+// View is a view into a column that allows access to individual elements by index.
+type View struct {
+	column Column
+	index  index.Int
+}
+
+// ItemAt returns the time at position i in the location of the column. Null values
+// are returned as the Unix epoch, use IsNull to tell them apart.
+func (v View) ItemAt(i int) time.Time {
+	return v.column.timeAt(v.index[i])
+}
+
+// IsNull returns true if the value at position i is null.
+func (v View) IsNull(i int) bool {
+	return v.column.nulls.Contains(v.index[i])
+}
+
+// Len returns the column length.
+func (v View) Len() int {
+	return len(v.index)
+}
+
+// Slice returns a slice containing a copy of the column data. Null values are nil.
+func (v View) Slice() []*time.Time {
+	result := make([]*time.Time, v.Len())
+	for i, j := range v.index {
+		if !v.column.nulls.Contains(j) {
+			t := v.column.timeAt(j)
+			result[i] = &t
+		}
+	}
+	return result
+}
@@ -0,0 +1,9 @@
+package narrow
+
+import "github.com/tobgu/qframe/types"
+
+// This file contains definitions for functions that need to be added manually for each data type.
+
+func (c Column) DataType() types.DataType {
+	return types.None
+}
@@ -11,8 +11,12 @@ import (
 // This file contains definitions for data and functions that need to be added
 // manually for each data type.
 
-// TODO: Probably need a more general aggregation pattern, int -> float (average for example)
-var aggregations = map[string]func([]genericDataType) genericDataType{}
+// aggregations holds the built in aggregation functions, func([]genericDataType) with
+// result type genericDataType, int or float64.
+var aggregations = map[string]interface{}{}
+
+// quantileAggregations holds built in aggregation functions that are parametrized by a quantile.
+var quantileAggregations = map[string]func(q float64) interface{}{}
 
 func (c Column) DataType() types.DataType {
 	return types.None
@@ -0,0 +1,7 @@
+package u16column
+
+import "github.com/tobgu/qframe/types"
+
+func (c Column) DataType() types.DataType {
+	return types.Uint16
+}
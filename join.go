@@ -0,0 +1,203 @@
+package qframe
+
+import (
+	"fmt"
+
+	"github.com/tobgu/qframe/config/join"
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/grouper"
+	"github.com/tobgu/qframe/internal/index"
+	qfstrings "github.com/tobgu/qframe/internal/strings"
+	"github.com/tobgu/qframe/qerrors"
+)
+
+func (qf QFrame) joinColumns(operation string, other QFrame, columns []string) ([]string, error) {
+	if len(columns) == 0 {
+		for _, col := range qf.columns {
+			if _, ok := other.columnsByName[col.name]; ok {
+				columns = append(columns, col.name)
+			}
+		}
+
+		if len(columns) == 0 {
+			return nil, qerrors.New(operation, "no common columns to join on")
+		}
+	}
+
+	if err := qf.checkColumns(operation, columns); err != nil {
+		return nil, err
+	}
+
+	if err := other.checkColumns(operation, columns); err != nil {
+		return nil, err
+	}
+
+	for _, col := range columns {
+		lType, rType := qf.columnsByName[col].DataType(), other.columnsByName[col].DataType()
+		if lType != rType {
+			return nil, qerrors.New(operation, "type mismatch for key column %s, %s != %s", col, lType, rType)
+		}
+	}
+
+	return columns, nil
+}
+
+// keyColumns returns the key columns of qf with the key columns of other appended. The rows
+// of qf are found at positions [0, qf.Len()) and the rows of other at [qf.Len(), qf.Len() + other.Len()).
+func (qf QFrame) keyColumns(operation string, other QFrame, columns []string) ([]column.Column, error) {
+	result := make([]column.Column, len(columns))
+	for i, name := range columns {
+		left := qf.columnsByName[name].Subset(qf.index)
+		right := other.columnsByName[name].Subset(other.index)
+		col, err := left.Append(right)
+		if err != nil {
+			return nil, qerrors.Propagate(fmt.Sprintf("%s column %s", operation, name), err)
+		}
+		result[i] = col
+	}
+
+	return result, nil
+}
+
+// Join combines the rows of this QFrame with the rows of other that have equal values in the
+// key columns. By default the QFrames are inner joined on all columns that they have in common.
+// See the join package for available configuration options.
+//
+// The resulting QFrame contains the columns of this QFrame followed by the non key columns of other.
+// Non key columns present in both QFrames are suffixed to tell them apart. Rows are ordered as in this
+// QFrame, rows matching more than one row in other are repeated once per match in the order they appear
+// in other. For right and outer joins rows in other that did not match any row in this QFrame are added
+// last, in the order they appear in other.
+//
+// Time complexity O((m + n) * k) where m and n = number of rows in the two QFrames, k = number of key columns.
+func (qf QFrame) Join(other QFrame, configFns ...join.ConfigFunc) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	if other.Err != nil {
+		return qf.withErr(qerrors.Propagate("Join", other.Err))
+	}
+
+	conf, err := join.NewConfig(configFns)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	columns, err := qf.joinColumns("Join", other, conf.Columns)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	keyColumns, err := qf.keyColumns("Join", other, columns)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	comparables := make([]column.Comparable, len(keyColumns))
+	for i, col := range keyColumns {
+		comparables[i] = col.Comparable(false, conf.JoinOnNull, false)
+	}
+
+	leftLen := uint32(qf.Len())
+	rightIx := make(index.Int, other.Len())
+	for i := range rightIx {
+		rightIx[i] = leftLen + uint32(i)
+	}
+
+	matches, unmatched, _ := grouper.Join(index.NewAscending(leftLen), rightIx, comparables)
+
+	// keyRows index the key columns, leftRows and rightRows index the columns of the QFrames.
+	keyRows, leftRows, rightRows := make(index.Int, 0, qf.Len()), make(index.Int, 0, qf.Len()), make(index.Int, 0, qf.Len())
+	keepLeft := conf.How == "left" || conf.How == "outer"
+	for i, match := range matches {
+		if match == nil {
+			if keepLeft {
+				keyRows = append(keyRows, uint32(i))
+				leftRows = append(leftRows, qf.index[i])
+				rightRows = append(rightRows, nullRow)
+			}
+			continue
+		}
+
+		for _, m := range match {
+			keyRows = append(keyRows, uint32(i))
+			leftRows = append(leftRows, qf.index[i])
+			rightRows = append(rightRows, other.index[m-leftLen])
+		}
+	}
+
+	if conf.How == "right" || conf.How == "outer" {
+		for _, m := range unmatched {
+			keyRows = append(keyRows, m)
+			leftRows = append(leftRows, nullRow)
+			rightRows = append(rightRows, other.index[m-leftLen])
+		}
+	}
+
+	keySet := qfstrings.NewStringSet(columns)
+	keyColumnsByName := make(map[string]column.Column, len(columns))
+	for i, name := range columns {
+		keyColumnsByName[name] = keyColumns[i]
+	}
+
+	newColumns := make([]namedColumn, 0, len(qf.columns)+len(other.columns)-len(columns))
+	newColumnsByName := make(map[string]namedColumn, cap(newColumns))
+	addColumn := func(name string, col column.Column) error {
+		if err := qfstrings.CheckName(name); err != nil {
+			return qerrors.Propagate("Join", err)
+		}
+
+		if _, ok := newColumnsByName[name]; ok {
+			return qerrors.New("Join", "duplicate column name in result: %s", name)
+		}
+
+		newCol := namedColumn{Column: col, name: name, pos: len(newColumns)}
+		newColumns = append(newColumns, newCol)
+		newColumnsByName[name] = newCol
+		return nil
+	}
+
+	for _, col := range qf.columns {
+		var newCol column.Column
+		name := col.name
+		if keySet.Contains(name) {
+			newCol = keyColumnsByName[name].Subset(keyRows)
+		} else {
+			if _, ok := other.columnsByName[name]; ok {
+				name += conf.LeftSuffix
+			}
+
+			newCol, err = nullableSubset(col.Column, leftRows)
+			if err != nil {
+				return qf.withErr(qerrors.Propagate(fmt.Sprintf("Join column %s", col.name), err))
+			}
+		}
+
+		if err := addColumn(name, newCol); err != nil {
+			return qf.withErr(err)
+		}
+	}
+
+	for _, col := range other.columns {
+		name := col.name
+		if keySet.Contains(name) {
+			continue
+		}
+
+		if _, ok := qf.columnsByName[name]; ok {
+			name += conf.RightSuffix
+		}
+
+		newCol, err := nullableSubset(col.Column, rightRows)
+		if err != nil {
+			return qf.withErr(qerrors.Propagate(fmt.Sprintf("Join column %s", col.name), err))
+		}
+
+		if err := addColumn(name, newCol); err != nil {
+			return qf.withErr(err)
+		}
+	}
+
+	return QFrame{columns: newColumns, columnsByName: newColumnsByName, index: index.NewAscending(uint32(len(keyRows)))}
+}
@@ -6,29 +6,45 @@ import (
 	"fmt"
 	"github.com/tobgu/qframe/config/rolling"
 	"io"
+	"math"
+	"math/rand"
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/tobgu/qframe/config/csv"
 	"github.com/tobgu/qframe/config/eval"
 	"github.com/tobgu/qframe/config/groupby"
+	"github.com/tobgu/qframe/config/interpolate"
 	"github.com/tobgu/qframe/config/newqf"
+	"github.com/tobgu/qframe/config/sample"
 	qsql "github.com/tobgu/qframe/config/sql"
+	"github.com/tobgu/qframe/decimal"
 	"github.com/tobgu/qframe/filter"
 	"github.com/tobgu/qframe/internal/bcolumn"
 	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/dcolumn"
 	"github.com/tobgu/qframe/internal/ecolumn"
+	"github.com/tobgu/qframe/internal/f32column"
 	"github.com/tobgu/qframe/internal/fcolumn"
 	"github.com/tobgu/qframe/internal/grouper"
+	"github.com/tobgu/qframe/internal/i16column"
+	"github.com/tobgu/qframe/internal/i32column"
+	"github.com/tobgu/qframe/internal/i8column"
 	"github.com/tobgu/qframe/internal/icolumn"
 	"github.com/tobgu/qframe/internal/index"
 	qfio "github.com/tobgu/qframe/internal/io"
 	qfsqlio "github.com/tobgu/qframe/internal/io/sql"
 	"github.com/tobgu/qframe/internal/math/integer"
+	qfrolling "github.com/tobgu/qframe/internal/rolling"
 	"github.com/tobgu/qframe/internal/scolumn"
 	qfsort "github.com/tobgu/qframe/internal/sort"
 	qfstrings "github.com/tobgu/qframe/internal/strings"
+	"github.com/tobgu/qframe/internal/tcolumn"
+	"github.com/tobgu/qframe/internal/u16column"
+	"github.com/tobgu/qframe/internal/u32column"
+	"github.com/tobgu/qframe/internal/u8column"
 	"github.com/tobgu/qframe/qerrors"
 	"github.com/tobgu/qframe/types"
 
@@ -112,6 +128,8 @@ func createColumn(name string, data interface{}, config *newqf.Config) (column.C
 	switch t := data.(type) {
 	case []int:
 		localS = icolumn.New(t)
+	case []*int:
+		localS = icolumn.NewPtrs(t)
 	case ConstInt:
 		localS = icolumn.NewConst(t.Val, t.Count)
 	case []float64:
@@ -119,7 +137,14 @@ func createColumn(name string, data interface{}, config *newqf.Config) (column.C
 	case ConstFloat:
 		localS = fcolumn.NewConst(t.Val, t.Count)
 	case []*string:
-		if values, ok := config.EnumColumns[name]; ok {
+		if _, ok := config.DecimalColumns[name]; ok {
+			localS, err = dcolumn.Parse(t)
+			if err != nil {
+				return nil, qerrors.Propagate(fmt.Sprintf("New columns %s", name), err)
+			}
+			// Book keeping
+			delete(config.DecimalColumns, name)
+		} else if values, ok := config.EnumColumns[name]; ok {
 			localS, err = ecolumn.New(t, values)
 			if err != nil {
 				return nil, qerrors.Propagate(fmt.Sprintf("New columns %s", name), err)
@@ -143,8 +168,38 @@ func createColumn(name string, data interface{}, config *newqf.Config) (column.C
 
 	case []bool:
 		localS = bcolumn.New(t)
+	case []*bool:
+		localS = bcolumn.NewPtrs(t)
 	case ConstBool:
 		localS = bcolumn.NewConst(t.Val, t.Count)
+	case []time.Time:
+		localS = tcolumn.New(t)
+	case []*time.Time:
+		localS = tcolumn.NewPtrs(t)
+	case []decimal.Decimal:
+		localS, err = dcolumn.New(t)
+		if err != nil {
+			return nil, qerrors.Propagate(fmt.Sprintf("New columns %s", name), err)
+		}
+	case []*decimal.Decimal:
+		localS, err = dcolumn.NewPtrs(t)
+		if err != nil {
+			return nil, qerrors.Propagate(fmt.Sprintf("New columns %s", name), err)
+		}
+	case []int8:
+		localS = i8column.New(t)
+	case []int16:
+		localS = i16column.New(t)
+	case []int32:
+		localS = i32column.New(t)
+	case []uint8:
+		localS = u8column.New(t)
+	case []uint16:
+		localS = u16column.New(t)
+	case []uint32:
+		localS = u32column.New(t)
+	case []float32:
+		localS = f32column.New(t)
 	case ecolumn.Column:
 		localS = t
 	case qfstrings.StringBlob:
@@ -218,6 +273,15 @@ func New(data map[string]types.DataSlice, fns ...newqf.ConfigFunc) QFrame {
 		return QFrame{Err: qerrors.New("New", "unknown enum columns: %v", colNames)}
 	}
 
+	if len(config.DecimalColumns) > 0 {
+		colNames := make([]string, 0)
+		for k := range config.DecimalColumns {
+			colNames = append(colNames, k)
+		}
+
+		return QFrame{Err: qerrors.New("New", "unknown decimal columns: %v", colNames)}
+	}
+
 	return QFrame{columns: columns, columnsByName: colByName, index: index.NewAscending(uint32(currentLen)), Err: nil}
 }
 
@@ -270,6 +334,11 @@ func (qf QFrame) filter(filters ...filter.Filter) QFrame {
 				return qf.withErr(qerrors.New("Filter", `unknown argument column: "%s"`, name))
 			}
 
+			// Allow comparison of narrow columns with columns of other types by temporarily widening them.
+			if s.DataType() != argC.DataType() {
+				s.Column, argC.Column = column.Widen(s.Column), column.Widen(argC.Column)
+			}
+
 			// Allow comparison of int and float columns by temporarily promoting int column to float.
 			// This is expensive compared to a comparison between columns of the same type and should be avoided
 			// if performance is critical.
@@ -389,20 +458,65 @@ func (qf QFrame) Sort(orders ...Order) QFrame {
 		return qf
 	}
 
+	comparables, err := qf.orderComparables("Sort", orders)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	newDf := qf.withIndex(qf.index.Copy())
+	sorter := qfsort.New(newDf.index, comparables)
+	sorter.Sort()
+	return newDf
+}
+
+// TopK returns a new QFrame containing the first k rows of the QFrame sorted according to the
+// orders specified. If the QFrame contains fewer than k rows all rows are returned, sorted.
+// If no orders are given the first k rows are returned.
+//
+// TopK is much cheaper than Sort followed by Slice when k is small compared to the number of rows.
+//
+// Time complexity O(m * n * log(k)) where m = number of columns to sort by, n = number of rows in QFrame.
+func (qf QFrame) TopK(k int, orders ...Order) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	if k < 0 {
+		return qf.withErr(qerrors.New("TopK", "k must be non negative"))
+	}
+
+	if k > qf.Len() {
+		k = qf.Len()
+	}
+
+	if len(orders) == 0 {
+		return qf.withIndex(qf.index[:k])
+	}
+
+	comparables, err := qf.orderComparables("TopK", orders)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	newDf := qf.withIndex(qf.index.Copy())
+	sorter := qfsort.New(newDf.index, comparables)
+	sorter.TopK(k)
+	newDf.index = newDf.index[:k]
+	return newDf
+}
+
+func (qf QFrame) orderComparables(fnName string, orders []Order) ([]column.Comparable, error) {
 	comparables := make([]column.Comparable, 0, len(orders))
 	for _, o := range orders {
 		s, ok := qf.columnsByName[o.Column]
 		if !ok {
-			return qf.withErr(qerrors.New("Sort", unknownCol(o.Column)))
+			return nil, qerrors.New(fnName, unknownCol(o.Column))
 		}
 
 		comparables = append(comparables, s.Comparable(o.Reverse, false, o.NullLast))
 	}
 
-	newDf := qf.withIndex(qf.index.Copy())
-	sorter := qfsort.New(newDf.index, comparables)
-	sorter.Sort()
-	return newDf
+	return comparables, nil
 }
 
 // ColumnNames returns the names of all columns in the QFrame.
@@ -466,6 +580,88 @@ func (qf QFrame) comparables(columns []string, orders []Order, groupByNull bool)
 	return result
 }
 
+// nullRow is used in subset indices to mark rows that should be null in the resulting column.
+const nullRow = math.MaxUint32
+
+// nullColumn returns a column with a single null element that can be appended to col.
+func nullColumn(col column.Column) (column.Column, error) {
+	switch col.DataType() {
+	case types.Int:
+		return icolumn.NewNull(1), nil
+	case types.Float:
+		return fcolumn.New([]float64{math.NaN()}), nil
+	case types.Bool:
+		return bcolumn.NewNull(1), nil
+	case types.String, types.Undefined:
+		return scolumn.New([]*string{nil}), nil
+	case types.Enum:
+		return ecolumn.New([]*string{nil}, nil)
+	case types.Time:
+		return tcolumn.NewNull(1), nil
+	case types.Decimal:
+		return dcolumn.NewNull(1), nil
+	case types.Int8:
+		return i8column.NewNull(1), nil
+	case types.Int16:
+		return i16column.NewNull(1), nil
+	case types.Int32:
+		return i32column.NewNull(1), nil
+	case types.Uint8:
+		return u8column.NewNull(1), nil
+	case types.Uint16:
+		return u16column.NewNull(1), nil
+	case types.Uint32:
+		return u32column.NewNull(1), nil
+	case types.Float32:
+		return f32column.NewNull(1), nil
+	default:
+		return nil, qerrors.New("nullColumn", "cannot represent null in %s column", col.DataType())
+	}
+}
+
+// intToFloat converts an int column to a float column, null is converted to NaN.
+func intToFloat(col column.Column) column.Column {
+	return fcolumn.New(col.(icolumn.Column).FloatSlice())
+}
+
+// nullableSubset works like Subset on col except that positions in ix equal to nullRow
+// will be null in the resulting column.
+func nullableSubset(col column.Column, ix index.Int) (column.Column, error) {
+	nonNullIx := make(index.Int, 0, len(ix))
+	for _, i := range ix {
+		if i != nullRow {
+			nonNullIx = append(nonNullIx, i)
+		}
+	}
+
+	if len(nonNullIx) == len(ix) {
+		return col.Subset(ix), nil
+	}
+
+	nullCol, err := nullColumn(col)
+	if err != nil {
+		return nil, err
+	}
+
+	withNullCol, err := col.Subset(nonNullIx).Append(nullCol)
+	if err != nil {
+		return nil, err
+	}
+
+	newIx := make(index.Int, len(ix))
+	pos := uint32(0)
+	for j, i := range ix {
+		if i == nullRow {
+			newIx[j] = uint32(len(nonNullIx))
+		} else {
+			newIx[j] = pos
+			pos++
+		}
+	}
+
+	return withNullCol.Subset(newIx), nil
+}
+
 // Distinct returns a new QFrame that only contains unique rows with respect to the specified columns.
 // If no columns are given Distinct will return rows where allow columns are unique.
 //
@@ -496,6 +692,40 @@ func (qf QFrame) Distinct(configFns ...groupby.ConfigFunc) QFrame {
 	return qf.withIndex(newIx)
 }
 
+// ValueCounts returns a new QFrame with the distinct non null values of col and the number of times
+// they occur in a column named "count". If normalize is true a column named "share" holding the
+// count divided by the number of non null values is also added.
+// The rows are sorted by descending count, rows with equal count by ascending value.
+//
+// Time complexity O(n + m * log(m)) where n = number of rows, m = number of distinct values.
+func (qf QFrame) ValueCounts(col string, normalize bool) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	if err := qf.checkColumns("ValueCounts", []string{col}); err != nil {
+		return qf.withErr(err)
+	}
+
+	if col == "count" || (normalize && col == "share") {
+		return qf.withErr(qerrors.New("ValueCounts", "column name clashes with result column: %s", col))
+	}
+
+	nonNull := qf.Filter(Filter{Column: col, Comparator: filter.IsNotNull})
+	counts := nonNull.GroupBy(groupby.Columns(col)).Aggregate(Aggregation{Fn: "count", Column: col, As: "count"})
+	if normalize {
+		total := float64(nonNull.Len())
+		counts = counts.Apply(Instruction{Fn: func(c int) float64 { return float64(c) / total }, DstCol: "share", SrcCol1: "count"})
+	}
+
+	counts = counts.Sort(Order{Column: "count", Reverse: true}, Order{Column: col})
+	if counts.Err != nil {
+		return qf.withErr(qerrors.Propagate("ValueCounts", counts.Err))
+	}
+
+	return counts
+}
+
 func (qf QFrame) checkColumns(operation string, columns []string) error {
 	for _, col := range columns {
 		if _, ok := qf.columnsByName[col]; !ok {
@@ -571,7 +801,7 @@ func (qf QFrame) GroupBy(configFns ...groupby.ConfigFunc) Grouper {
 		return Grouper{Err: err}
 	}
 
-	g := Grouper{columns: qf.columns, columnsByName: qf.columnsByName, groupedColumns: config.Columns}
+	g := Grouper{columns: qf.columns, columnsByName: qf.columnsByName, groupedColumns: config.Columns, index: qf.index}
 	if qf.Len() == 0 {
 		return g
 	}
@@ -589,6 +819,70 @@ func (qf QFrame) GroupBy(configFns ...groupby.ConfigFunc) Grouper {
 	return g
 }
 
+// rollingColumn applies fn to rolling windows over srcCol within each of the groups of rows in indices.
+func rollingColumn(fn types.SliceFuncOrBuiltInId, srcCol string, columnsByName map[string]namedColumn, indices []index.Int, conf rolling.Config) (column.Column, error) {
+	namedColumn, ok := columnsByName[srcCol]
+	if !ok {
+		return nil, qerrors.New("Rolling", unknownCol(srcCol))
+	}
+
+	var intervalColumn column.Column
+	if conf.IntervalFunc != nil {
+		intervalCol, ok := columnsByName[conf.IntervalColName]
+		if !ok {
+			return nil, qerrors.New("Rolling", unknownCol(conf.IntervalColName))
+		}
+		intervalColumn = intervalCol.Column
+	}
+
+	size := 0
+	for _, ix := range indices {
+		size += len(ix)
+	}
+
+	// Windows of all groups are combined into one slice with positions referring to the
+	// combined index of all groups. This way each window stays within its own group.
+	ix := make(index.Int, 0, size)
+	windows := make([]qfrolling.Window, 0, size)
+	for _, groupIx := range indices {
+		var groupWindows []qfrolling.Window
+		if intervalColumn != nil {
+			var err error
+			groupWindows, err = intervalColumn.IntervalWindows(conf.IntervalFunc, groupIx, conf.Position)
+			if err != nil {
+				return nil, qerrors.Propagate("Rolling", err)
+			}
+		} else {
+			groupWindows = qfrolling.FixedWindows(len(groupIx), conf.WindowSize, conf.Position)
+		}
+
+		offset := len(ix)
+		for _, w := range groupWindows {
+			w.Start += offset
+			w.End += offset
+			windows = append(windows, w)
+		}
+		ix = append(ix, groupIx...)
+	}
+
+	resultColumn, err := namedColumn.Rolling(fn, ix, windows, conf.PadValue)
+	if err != nil {
+		return nil, qerrors.Propagate("Rolling", err)
+	}
+
+	return resultColumn, nil
+}
+
+// Rolling applies fn to a rolling window over srcCol and stores the result in dstCol. See the rolling
+// package for available configuration options.
+//
+// fn may be a built in aggregation function or a function taking a slice of the column type and
+// returning a single value of the same type. For string and enum columns fn is a func([]*string) *string,
+// the result of rolling over an enum column is a string column. The pad value for string and enum
+// columns may be given as a string or a *string, use a nil *string to pad with null. Functions returning
+// an int, like the built in count_distinct, produce an int column and take an int pad value.
+//
+// Time complexity O(n * m) where n = number of rows, m = window size.
 func (qf QFrame) Rolling(fn types.SliceFuncOrBuiltInId, dstCol, srcCol string, configFns ...rolling.ConfigFunc) QFrame {
 	if qf.Err != nil {
 		return qf
@@ -599,15 +893,126 @@ func (qf QFrame) Rolling(fn types.SliceFuncOrBuiltInId, dstCol, srcCol string, c
 		return qf.withErr(err)
 	}
 
-	namedColumn, ok := qf.columnsByName[srcCol]
+	resultColumn, err := rollingColumn(fn, srcCol, qf.columnsByName, []index.Int{qf.index}, conf)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	return qf.setColumn(dstCol, resultColumn)
+}
+
+// interpolateColumn fills the NaN gaps of srcCol within each of the groups of rows in indices.
+func interpolateColumn(srcCol string, columnsByName map[string]namedColumn, indices []index.Int, conf interpolate.Config) (column.Column, error) {
+	namedColumn, ok := columnsByName[srcCol]
 	if !ok {
-		return qf.withErr(qerrors.New("Rolling", unknownCol(srcCol)))
+		return nil, qerrors.New("Interpolate", unknownCol(srcCol))
 	}
 
-	srcColumn := namedColumn.Column
-	resultColumn, err := srcColumn.Rolling(fn, qf.index, conf)
+	srcColumn, ok := column.Widen(namedColumn.Column).(fcolumn.Column)
+	if !ok {
+		return nil, qerrors.New("Interpolate", "cannot interpolate %s column %s, only float columns supported", namedColumn.DataType(), srcCol)
+	}
+
+	var xColumn column.Column
+	if conf.XColName != "" {
+		xCol, ok := columnsByName[conf.XColName]
+		if !ok {
+			return nil, qerrors.New("Interpolate", unknownCol(conf.XColName))
+		}
+
+		switch xCol.DataType() {
+		case types.Int, types.Float, types.Int8, types.Int16, types.Int32, types.Uint8, types.Uint16, types.Uint32, types.Float32:
+		default:
+			return nil, qerrors.New("Interpolate", "x column %s must be an int or float column, was %s", conf.XColName, xCol.DataType())
+		}
+		xColumn = xCol.Column
+	}
+
+	values := srcColumn.View(index.NewAscending(uint32(srcColumn.Len()))).Slice()
+	for _, ix := range indices {
+		// Positions in the group, or their x values, of all rows
+		xs := make([]float64, len(ix))
+		if xColumn != nil {
+			nulls, err := nullPositions(xColumn, ix)
+			if err != nil {
+				return nil, qerrors.Propagate("Interpolate", err)
+			}
+
+			for _, isNull := range nulls {
+				if isNull {
+					return nil, qerrors.New("Interpolate", "null values in x column %s", conf.XColName)
+				}
+			}
+			xs = floatValues(xColumn, ix)
+		} else {
+			for i := range xs {
+				xs[i] = float64(i)
+			}
+		}
+
+		interpolateGroup(values, ix, xs, conf)
+	}
+
+	return fcolumn.New(values), nil
+}
+
+// interpolateGroup fills the gaps of NaN values in values at the positions in ix. Gaps before the
+// first or after the last known value are not filled.
+func interpolateGroup(values []float64, ix index.Int, xs []float64, conf interpolate.Config) {
+	prev := -1
+	for i, pos := range ix {
+		if math.IsNaN(values[pos]) {
+			continue
+		}
+
+		gap := i - prev - 1
+		if prev >= 0 && gap > 0 && (conf.MaxGap == 0 || gap <= conf.MaxGap) {
+			x0, x1 := xs[prev], xs[i]
+			y0, y1 := values[ix[prev]], values[pos]
+			for j := prev + 1; j < i; j++ {
+				switch conf.Method {
+				case "linear":
+					if x1 == x0 {
+						values[ix[j]] = y0
+					} else {
+						values[ix[j]] = y0 + (y1-y0)*(xs[j]-x0)/(x1-x0)
+					}
+				case "nearest":
+					if xs[j]-x0 <= x1-xs[j] {
+						values[ix[j]] = y0
+					} else {
+						values[ix[j]] = y1
+					}
+				case "previous":
+					values[ix[j]] = y0
+				}
+			}
+		}
+
+		prev = i
+	}
+}
+
+// Interpolate fills gaps of missing values (NaN) in the float column srcCol and stores the result in dstCol.
+// Only gaps between two known values are filled. See the interpolate package for available configuration
+// options, among them the interpolation method and the use of another column as x axis.
+//
+// Float32 columns are widened, the result is always a float column.
+//
+// Time complexity O(n) where n = number of rows.
+func (qf QFrame) Interpolate(dstCol, srcCol string, configFns ...interpolate.ConfigFunc) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	conf, err := interpolate.NewConfig(configFns)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	resultColumn, err := interpolateColumn(srcCol, qf.columnsByName, []index.Int{qf.index}, conf)
 	if err != nil {
-		return qf.withErr(qerrors.Propagate("Rolling", err))
+		return qf.withErr(err)
 	}
 
 	return qf.setColumn(dstCol, resultColumn)
@@ -698,6 +1103,109 @@ func (qf QFrame) Slice(start, end int) QFrame {
 	return qf.withIndex(qf.index[start:end])
 }
 
+// Sample returns a new QFrame with n randomly selected rows. The rows are sampled without
+// replacement unless configured otherwise, in random order. See package config/sample for
+// further configuration options, among them sampling of a fraction of the rows and sampling
+// per group.
+//
+// Like Slice the sample shares the underlying storage with the original QFrame.
+//
+// Time complexity O(n) where n = number of rows in QFrame, O(m * n) where m = number of columns
+// to group by when sampling per group.
+func (qf QFrame) Sample(n int, configFns ...sample.ConfigFunc) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	config := sample.NewConfig(configFns)
+	if err := qf.checkColumns("Sample", config.Columns); err != nil {
+		return qf.withErr(err)
+	}
+
+	if config.UseFraction && config.Fraction < 0 {
+		return qf.withErr(qerrors.New("Sample", "fraction must be non negative"))
+	}
+
+	seed := config.Seed
+	if !config.UseSeed {
+		seed = time.Now().UnixNano()
+	}
+	rnd := rand.New(rand.NewSource(seed))
+
+	groups := []index.Int{qf.index}
+	if len(config.Columns) > 0 && qf.Len() > 0 {
+		orders := qf.orders(config.Columns)
+		comparables := qf.comparables(config.Columns, orders, true)
+		groups, _ = grouper.GroupBy(qf.index, comparables)
+
+		// The groups are returned in hash order, which varies between processes.
+		// Order them by first row to make the result depend on the seed only.
+		sort.Slice(groups, func(i, j int) bool { return groups[i][0] < groups[j][0] })
+	}
+
+	newIx := make(index.Int, 0)
+	for _, ix := range groups {
+		count := n
+		if config.UseFraction {
+			count = int(math.Round(config.Fraction * float64(len(ix))))
+		}
+
+		sampleIx, err := sampleIndex(ix, count, config.Replace, rnd)
+		if err != nil {
+			return qf.withErr(qerrors.Propagate("Sample", err))
+		}
+		newIx = append(newIx, sampleIx...)
+	}
+
+	return qf.withIndex(newIx)
+}
+
+func sampleIndex(ix index.Int, n int, replace bool, rnd *rand.Rand) (index.Int, error) {
+	if n < 0 {
+		return nil, qerrors.New("sampleIndex", "sample size must be non negative, was %d", n)
+	}
+
+	result := make(index.Int, n)
+	if replace {
+		if n > 0 && len(ix) == 0 {
+			return nil, qerrors.New("sampleIndex", "cannot sample from zero rows")
+		}
+
+		for i := range result {
+			result[i] = ix[rnd.Intn(len(ix))]
+		}
+		return result, nil
+	}
+
+	if n > len(ix) {
+		return nil, qerrors.New("sampleIndex", "cannot sample %d rows from %d rows without replacement", n, len(ix))
+	}
+
+	// Partial Fisher-Yates shuffle of a copy of the index
+	ix = ix.Copy()
+	for i := 0; i < n; i++ {
+		j := i + rnd.Intn(len(ix)-i)
+		ix[i], ix[j] = ix[j], ix[i]
+	}
+	copy(result, ix[:n])
+	return result, nil
+}
+
+// Shuffle returns a new QFrame with the rows in random order. Shuffling the same QFrame
+// using the same seed always produces the same order.
+//
+// Time complexity O(n) where n = number of rows in QFrame.
+func (qf QFrame) Shuffle(seed int64) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	newIx := qf.index.Copy()
+	rnd := rand.New(rand.NewSource(seed))
+	rnd.Shuffle(len(newIx), func(i, j int) { newIx[i], newIx[j] = newIx[j], newIx[i] })
+	return qf.withIndex(newIx)
+}
+
 func (qf QFrame) setColumn(name string, c column.Column) QFrame {
 	if err := qfstrings.CheckName(name); err != nil {
 		return qf.withErr(qerrors.Propagate("setColumn", err))
@@ -830,23 +1338,75 @@ func (qf QFrame) apply1(fn types.DataFuncOrBuiltInId, dstCol, srcCol string) QFr
 		return qf.withErr(qerrors.Propagate("apply1", err))
 	}
 
-	var resultColumn column.Column
-	switch t := sliceResult.(type) {
+	resultColumn, err := newResultColumn("apply1", sliceResult)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	if resultColumn, err = propagateNulls(resultColumn, srcColumn); err != nil {
+		return qf.withErr(qerrors.Propagate("apply1", err))
+	}
+
+	return qf.setColumn(dstCol, resultColumn)
+}
+
+// hasNullSet returns true for the column types that keep track of null values separately from the data.
+func hasNullSet(dataType types.DataType) bool {
+	switch dataType {
+	case types.Int, types.Bool, types.Time, types.Decimal,
+		types.Int8, types.Int16, types.Int32, types.Uint8, types.Uint16, types.Uint32:
+		return true
+	default:
+		return false
+	}
+}
+
+// propagateNulls returns col with all rows that are null in any of srcCols set to null.
+// Only int, bool, time and decimal columns are considered, the functions applied to other column
+// types are passed the null values, NaN or nil, and decide the result themselves.
+func propagateNulls(col column.Column, srcCols ...column.Column) (column.Column, error) {
+	ix := index.NewAscending(uint32(col.Len()))
+	bIndex := index.NewBool(col.Len())
+	for _, src := range srcCols {
+		if hasNullSet(src.DataType()) {
+			if err := src.Filter(ix, filter.IsNull, nil, bIndex); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	hasNulls := false
+	for i, isNull := range bIndex {
+		if isNull {
+			ix[i] = nullRow
+			hasNulls = true
+		}
+	}
+
+	if !hasNulls {
+		return col, nil
+	}
+
+	return nullableSubset(col, ix)
+}
+
+// newResultColumn creates a column from the result of a column operation that may
+// be either a column or a slice of data, depending on the type of the result.
+func newResultColumn(operation string, result interface{}) (column.Column, error) {
+	switch t := result.(type) {
 	case []int:
-		resultColumn = icolumn.New(t)
+		return icolumn.New(t), nil
 	case []float64:
-		resultColumn = fcolumn.New(t)
+		return fcolumn.New(t), nil
 	case []bool:
-		resultColumn = bcolumn.New(t)
+		return bcolumn.New(t), nil
 	case []*string:
-		resultColumn = scolumn.New(t)
+		return scolumn.New(t), nil
 	case column.Column:
-		resultColumn = t
+		return t, nil
 	default:
-		return qf.withErr(qerrors.New("apply1", "unexpected type of new columns %#v", t))
+		return nil, qerrors.New(operation, "unexpected type of new columns %#v", t)
 	}
-
-	return qf.setColumn(dstCol, resultColumn)
 }
 
 // apply2 is a helper function for zero argument applies.
@@ -867,11 +1427,20 @@ func (qf QFrame) apply2(fn types.DataFuncOrBuiltInId, dstCol, srcCol1, srcCol2 s
 	}
 	srcColumn2 := namedSrcColumn2.Column
 
+	// Narrow columns are widened to allow them to be combined with 64 bit columns
+	if srcColumn1.DataType() != srcColumn2.DataType() {
+		srcColumn1, srcColumn2 = column.Widen(srcColumn1), column.Widen(srcColumn2)
+	}
+
 	resultColumn, err := srcColumn1.Apply2(fn, srcColumn2, qf.index)
 	if err != nil {
 		return qf.withErr(qerrors.Propagate("apply2", err))
 	}
 
+	if resultColumn, err = propagateNulls(resultColumn, srcColumn1, srcColumn2); err != nil {
+		return qf.withErr(qerrors.Propagate("apply2", err))
+	}
+
 	return qf.setColumn(dstCol, resultColumn)
 }
 
@@ -975,7 +1544,11 @@ func (qf QFrame) functionType(name string) (types.FunctionType, error) {
 ////////////
 
 // ReadCSV returns a QFrame with data, in CSV format, taken from reader.
-// Column data types are auto detected if not explicitly specified.
+// Column data types are auto detected if not explicitly specified. Time columns are
+// never auto detected, their values are parsed according to the csv.TimeLayout option.
+// Decimal columns are never auto detected either, their scale is the largest number of
+// decimals found in the column. Use the csv.InferNullable option to auto detect int and
+// bool columns with null values.
 //
 // Time complexity O(m * n) where m = number of columns, n = number of rows.
 func ReadCSV(reader io.Reader, confFuncs ...csv.ConfigFunc) QFrame {
@@ -990,9 +1563,15 @@ func ReadCSV(reader io.Reader, confFuncs ...csv.ConfigFunc) QFrame {
 
 // ReadJSON returns a QFrame with data, in JSON format, taken from reader.
 //
+// Column types are inferred from the first non null value in each column. Numeric columns
+// become float columns with null represented by NaN. Use the newqf.InferInts option to read
+// numeric columns holding only integers into int columns instead, and the newqf.Decimals
+// option to read numbers exactly into decimal columns.
+//
 // Time complexity O(m * n) where m = number of columns, n = number of rows.
 func ReadJSON(reader io.Reader, fns ...newqf.ConfigFunc) QFrame {
-	data, err := qfio.UnmarshalJSON(reader)
+	conf := newqf.NewConfig(fns)
+	data, err := qfio.UnmarshalJSON(reader, conf.DecimalColumns, conf.InferInts)
 	if err != nil {
 		return QFrame{Err: err}
 	}
@@ -1001,6 +1580,8 @@ func ReadJSON(reader io.Reader, fns ...newqf.ConfigFunc) QFrame {
 }
 
 // ReadSQL returns a QFrame by reading the results of a SQL query.
+// Values scanned as time.Time by the driver result in time columns. Use the
+// sql.TextToDecimal coercion to read NUMERIC and DECIMAL columns exactly.
 func ReadSQL(tx *sql.Tx, confFuncs ...qsql.ConfigFunc) QFrame {
 	conf := qsql.NewConfig(confFuncs)
 	// The MySQL can only use prepared
@@ -1024,6 +1605,8 @@ func ReadSQL(tx *sql.Tx, confFuncs ...qsql.ConfigFunc) QFrame {
 }
 
 // ToCSV writes the data in the QFrame, in CSV format, to writer.
+// Time values are written in RFC3339 format with nanoseconds. Decimal values are written
+// with the scale of the column, eg. "12.50".
 //
 // Time complexity O(m * n) where m = number of rows, n = number of columns.
 //
@@ -1066,6 +1649,8 @@ func (qf QFrame) ToCSV(writer io.Writer) error {
 }
 
 // ToJSON writes the data in the QFrame, in JSON format one record per row, to writer.
+// Time values are written as strings in RFC3339 format with nanoseconds. Decimal values are
+// written as numbers with the scale of the column.
 //
 // Time complexity O(m * n) where m = number of rows, n = number of columns.
 func (qf QFrame) ToJSON(writer io.Writer) error {
@@ -1171,11 +1756,13 @@ func Doc() string {
 	result := fmt.Sprintf("Default context\n===============\n%s\n", eval.NewDefaultCtx())
 	result += "\nColumns\n=======\n\n"
 	for typeName, docString := range map[types.DataType]string{
-		types.Bool:   bcolumn.Doc(),
-		types.Enum:   ecolumn.Doc(),
-		types.Float:  fcolumn.Doc(),
-		types.Int:    icolumn.Doc(),
-		types.String: scolumn.Doc()} {
+		types.Bool:    bcolumn.Doc(),
+		types.Enum:    ecolumn.Doc(),
+		types.Float:   fcolumn.Doc(),
+		types.Int:     icolumn.Doc(),
+		types.String:  scolumn.Doc(),
+		types.Time:    tcolumn.Doc(),
+		types.Decimal: dcolumn.Doc()} {
 		result += fmt.Sprintf("%s\n%s\n%s\n", strings.Title(string(typeName)), strings.Repeat("-", len(typeName)), docString)
 	}
 
@@ -1183,10 +1770,6 @@ func Doc() string {
 }
 
 // TODO?
-// - It would also be nice if null could be interpreted as NaN for floats when reading JSON. Should not be impossible
-//   using the generated easyjson code as starting point for columns based format and by refining type
-//   detection for the record based read. That would also allow proper parsing of integers for record
-//   format rather than making them floats.
 // - Support access by x, y (to support GoNum matrix interface), or support returning a data type that supports that
 //   interface.
 // - More serialization and deserialization tests
@@ -1194,8 +1777,6 @@ func Doc() string {
 // - ApplyN?
 // - Are special cases in aggregations that do not rely on index order worth the extra code for the increase in
 //   performance allowed by avoiding use of the index?
-// - Optional specification of destination column for aggregations, to be able to do 50perc, 90perc, 99perc in one
-//   aggregation for example.
 // - Equals should support an option to ignore column orders in the QFrame.
 
 // TODO performance?
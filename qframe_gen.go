@@ -2,10 +2,19 @@ package qframe
 
 import (
 	"github.com/tobgu/qframe/internal/bcolumn"
+	"github.com/tobgu/qframe/internal/dcolumn"
 	"github.com/tobgu/qframe/internal/ecolumn"
+	"github.com/tobgu/qframe/internal/f32column"
 	"github.com/tobgu/qframe/internal/fcolumn"
+	"github.com/tobgu/qframe/internal/i16column"
+	"github.com/tobgu/qframe/internal/i32column"
+	"github.com/tobgu/qframe/internal/i8column"
 	"github.com/tobgu/qframe/internal/icolumn"
 	"github.com/tobgu/qframe/internal/scolumn"
+	"github.com/tobgu/qframe/internal/tcolumn"
+	"github.com/tobgu/qframe/internal/u16column"
+	"github.com/tobgu/qframe/internal/u32column"
+	"github.com/tobgu/qframe/internal/u8column"
 	"github.com/tobgu/qframe/qerrors"
 )
 
@@ -215,3 +224,372 @@ func (qf QFrame) MustEnumView(colName string) EnumView {
 	}
 	return view
 }
+
+// TimeView provides a "view" into an time column and can be used for access to individual elements.
+type TimeView struct {
+	tcolumn.View
+}
+
+// TimeView returns a view into an time column identified by name.
+//
+// colName - Name of the column.
+//
+// Returns an error if the column is missing or of wrong type.
+// Time complexity O(1).
+func (qf QFrame) TimeView(colName string) (TimeView, error) {
+	namedColumn, ok := qf.columnsByName[colName]
+	if !ok {
+		return TimeView{}, qerrors.New("TimeView", "unknown column: %s", colName)
+	}
+
+	col, ok := namedColumn.Column.(tcolumn.Column)
+	if !ok {
+		return TimeView{}, qerrors.New(
+			"TimeView",
+			"invalid column type, expected: %s, was: %s", "time", namedColumn.DataType())
+	}
+
+	return TimeView{View: col.View(qf.index)}, nil
+}
+
+// MustTimeView returns a view into an time column identified by name.
+//
+// colName - Name of the column.
+//
+// Panics if the column is missing or of wrong type.
+// Time complexity 0(1).
+func (qf QFrame) MustTimeView(colName string) TimeView {
+	view, err := qf.TimeView(colName)
+	if err != nil {
+		panic(qerrors.Propagate("MustTimeView", err))
+	}
+	return view
+}
+
+// DecimalView provides a "view" into an decimal column and can be used for access to individual elements.
+type DecimalView struct {
+	dcolumn.View
+}
+
+// DecimalView returns a view into an decimal column identified by name.
+//
+// colName - Name of the column.
+//
+// Returns an error if the column is missing or of wrong type.
+// Time complexity O(1).
+func (qf QFrame) DecimalView(colName string) (DecimalView, error) {
+	namedColumn, ok := qf.columnsByName[colName]
+	if !ok {
+		return DecimalView{}, qerrors.New("DecimalView", "unknown column: %s", colName)
+	}
+
+	col, ok := namedColumn.Column.(dcolumn.Column)
+	if !ok {
+		return DecimalView{}, qerrors.New(
+			"DecimalView",
+			"invalid column type, expected: %s, was: %s", "decimal", namedColumn.DataType())
+	}
+
+	return DecimalView{View: col.View(qf.index)}, nil
+}
+
+// MustDecimalView returns a view into an decimal column identified by name.
+//
+// colName - Name of the column.
+//
+// Panics if the column is missing or of wrong type.
+// Time complexity 0(1).
+func (qf QFrame) MustDecimalView(colName string) DecimalView {
+	view, err := qf.DecimalView(colName)
+	if err != nil {
+		panic(qerrors.Propagate("MustDecimalView", err))
+	}
+	return view
+}
+
+// Int8View provides a "view" into an int8 column and can be used for access to individual elements.
+type Int8View struct {
+	i8column.View
+}
+
+// Int8View returns a view into an int8 column identified by name.
+//
+// colName - Name of the column.
+//
+// Returns an error if the column is missing or of wrong type.
+// Time complexity O(1).
+func (qf QFrame) Int8View(colName string) (Int8View, error) {
+	namedColumn, ok := qf.columnsByName[colName]
+	if !ok {
+		return Int8View{}, qerrors.New("Int8View", "unknown column: %s", colName)
+	}
+
+	col, ok := namedColumn.Column.(i8column.Column)
+	if !ok {
+		return Int8View{}, qerrors.New(
+			"Int8View",
+			"invalid column type, expected: %s, was: %s", "int8", namedColumn.DataType())
+	}
+
+	return Int8View{View: col.View(qf.index)}, nil
+}
+
+// MustInt8View returns a view into an int8 column identified by name.
+//
+// colName - Name of the column.
+//
+// Panics if the column is missing or of wrong type.
+// Time complexity 0(1).
+func (qf QFrame) MustInt8View(colName string) Int8View {
+	view, err := qf.Int8View(colName)
+	if err != nil {
+		panic(qerrors.Propagate("MustInt8View", err))
+	}
+	return view
+}
+
+// Int16View provides a "view" into an int16 column and can be used for access to individual elements.
+type Int16View struct {
+	i16column.View
+}
+
+// Int16View returns a view into an int16 column identified by name.
+//
+// colName - Name of the column.
+//
+// Returns an error if the column is missing or of wrong type.
+// Time complexity O(1).
+func (qf QFrame) Int16View(colName string) (Int16View, error) {
+	namedColumn, ok := qf.columnsByName[colName]
+	if !ok {
+		return Int16View{}, qerrors.New("Int16View", "unknown column: %s", colName)
+	}
+
+	col, ok := namedColumn.Column.(i16column.Column)
+	if !ok {
+		return Int16View{}, qerrors.New(
+			"Int16View",
+			"invalid column type, expected: %s, was: %s", "int16", namedColumn.DataType())
+	}
+
+	return Int16View{View: col.View(qf.index)}, nil
+}
+
+// MustInt16View returns a view into an int16 column identified by name.
+//
+// colName - Name of the column.
+//
+// Panics if the column is missing or of wrong type.
+// Time complexity 0(1).
+func (qf QFrame) MustInt16View(colName string) Int16View {
+	view, err := qf.Int16View(colName)
+	if err != nil {
+		panic(qerrors.Propagate("MustInt16View", err))
+	}
+	return view
+}
+
+// Int32View provides a "view" into an int32 column and can be used for access to individual elements.
+type Int32View struct {
+	i32column.View
+}
+
+// Int32View returns a view into an int32 column identified by name.
+//
+// colName - Name of the column.
+//
+// Returns an error if the column is missing or of wrong type.
+// Time complexity O(1).
+func (qf QFrame) Int32View(colName string) (Int32View, error) {
+	namedColumn, ok := qf.columnsByName[colName]
+	if !ok {
+		return Int32View{}, qerrors.New("Int32View", "unknown column: %s", colName)
+	}
+
+	col, ok := namedColumn.Column.(i32column.Column)
+	if !ok {
+		return Int32View{}, qerrors.New(
+			"Int32View",
+			"invalid column type, expected: %s, was: %s", "int32", namedColumn.DataType())
+	}
+
+	return Int32View{View: col.View(qf.index)}, nil
+}
+
+// MustInt32View returns a view into an int32 column identified by name.
+//
+// colName - Name of the column.
+//
+// Panics if the column is missing or of wrong type.
+// Time complexity 0(1).
+func (qf QFrame) MustInt32View(colName string) Int32View {
+	view, err := qf.Int32View(colName)
+	if err != nil {
+		panic(qerrors.Propagate("MustInt32View", err))
+	}
+	return view
+}
+
+// Uint8View provides a "view" into an uint8 column and can be used for access to individual elements.
+type Uint8View struct {
+	u8column.View
+}
+
+// Uint8View returns a view into an uint8 column identified by name.
+//
+// colName - Name of the column.
+//
+// Returns an error if the column is missing or of wrong type.
+// Time complexity O(1).
+func (qf QFrame) Uint8View(colName string) (Uint8View, error) {
+	namedColumn, ok := qf.columnsByName[colName]
+	if !ok {
+		return Uint8View{}, qerrors.New("Uint8View", "unknown column: %s", colName)
+	}
+
+	col, ok := namedColumn.Column.(u8column.Column)
+	if !ok {
+		return Uint8View{}, qerrors.New(
+			"Uint8View",
+			"invalid column type, expected: %s, was: %s", "uint8", namedColumn.DataType())
+	}
+
+	return Uint8View{View: col.View(qf.index)}, nil
+}
+
+// MustUint8View returns a view into an uint8 column identified by name.
+//
+// colName - Name of the column.
+//
+// Panics if the column is missing or of wrong type.
+// Time complexity 0(1).
+func (qf QFrame) MustUint8View(colName string) Uint8View {
+	view, err := qf.Uint8View(colName)
+	if err != nil {
+		panic(qerrors.Propagate("MustUint8View", err))
+	}
+	return view
+}
+
+// Uint16View provides a "view" into an uint16 column and can be used for access to individual elements.
+type Uint16View struct {
+	u16column.View
+}
+
+// Uint16View returns a view into an uint16 column identified by name.
+//
+// colName - Name of the column.
+//
+// Returns an error if the column is missing or of wrong type.
+// Time complexity O(1).
+func (qf QFrame) Uint16View(colName string) (Uint16View, error) {
+	namedColumn, ok := qf.columnsByName[colName]
+	if !ok {
+		return Uint16View{}, qerrors.New("Uint16View", "unknown column: %s", colName)
+	}
+
+	col, ok := namedColumn.Column.(u16column.Column)
+	if !ok {
+		return Uint16View{}, qerrors.New(
+			"Uint16View",
+			"invalid column type, expected: %s, was: %s", "uint16", namedColumn.DataType())
+	}
+
+	return Uint16View{View: col.View(qf.index)}, nil
+}
+
+// MustUint16View returns a view into an uint16 column identified by name.
+//
+// colName - Name of the column.
+//
+// Panics if the column is missing or of wrong type.
+// Time complexity 0(1).
+func (qf QFrame) MustUint16View(colName string) Uint16View {
+	view, err := qf.Uint16View(colName)
+	if err != nil {
+		panic(qerrors.Propagate("MustUint16View", err))
+	}
+	return view
+}
+
+// Uint32View provides a "view" into an uint32 column and can be used for access to individual elements.
+type Uint32View struct {
+	u32column.View
+}
+
+// Uint32View returns a view into an uint32 column identified by name.
+//
+// colName - Name of the column.
+//
+// Returns an error if the column is missing or of wrong type.
+// Time complexity O(1).
+func (qf QFrame) Uint32View(colName string) (Uint32View, error) {
+	namedColumn, ok := qf.columnsByName[colName]
+	if !ok {
+		return Uint32View{}, qerrors.New("Uint32View", "unknown column: %s", colName)
+	}
+
+	col, ok := namedColumn.Column.(u32column.Column)
+	if !ok {
+		return Uint32View{}, qerrors.New(
+			"Uint32View",
+			"invalid column type, expected: %s, was: %s", "uint32", namedColumn.DataType())
+	}
+
+	return Uint32View{View: col.View(qf.index)}, nil
+}
+
+// MustUint32View returns a view into an uint32 column identified by name.
+//
+// colName - Name of the column.
+//
+// Panics if the column is missing or of wrong type.
+// Time complexity 0(1).
+func (qf QFrame) MustUint32View(colName string) Uint32View {
+	view, err := qf.Uint32View(colName)
+	if err != nil {
+		panic(qerrors.Propagate("MustUint32View", err))
+	}
+	return view
+}
+
+// Float32View provides a "view" into an float32 column and can be used for access to individual elements.
+type Float32View struct {
+	f32column.View
+}
+
+// Float32View returns a view into an float32 column identified by name.
+//
+// colName - Name of the column.
+//
+// Returns an error if the column is missing or of wrong type.
+// Time complexity O(1).
+func (qf QFrame) Float32View(colName string) (Float32View, error) {
+	namedColumn, ok := qf.columnsByName[colName]
+	if !ok {
+		return Float32View{}, qerrors.New("Float32View", "unknown column: %s", colName)
+	}
+
+	col, ok := namedColumn.Column.(f32column.Column)
+	if !ok {
+		return Float32View{}, qerrors.New(
+			"Float32View",
+			"invalid column type, expected: %s, was: %s", "float32", namedColumn.DataType())
+	}
+
+	return Float32View{View: col.View(qf.index)}, nil
+}
+
+// MustFloat32View returns a view into an float32 column identified by name.
+//
+// colName - Name of the column.
+//
+// Panics if the column is missing or of wrong type.
+// Time complexity 0(1).
+func (qf QFrame) MustFloat32View(colName string) Float32View {
+	view, err := qf.Float32View(colName)
+	if err != nil {
+		panic(qerrors.Propagate("MustFloat32View", err))
+	}
+	return view
+}
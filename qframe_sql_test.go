@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/tobgu/qframe"
 	qsql "github.com/tobgu/qframe/config/sql"
@@ -174,6 +175,81 @@ func TestQFrame_ReadSQL(t *testing.T) {
 	assertEquals(t, expected, qf)
 }
 
+func TestQFrame_ToSQLNull(t *testing.T) {
+	one, tr := 1, true
+	dvr := MockDriver{t: t}
+	dvr.query = "INSERT INTO test (COL1,COL2) VALUES (?,?);"
+	dvr.args.values = [][]driver.Value{
+		{int64(1), nil},
+		{nil, true},
+	}
+	sql.Register("TestToSQLNull", dvr)
+	db, _ := sql.Open("TestToSQLNull", "")
+	tx, _ := db.Begin()
+	qf := qframe.New(map[string]interface{}{
+		"COL1": []*int{&one, nil},
+		"COL2": []*bool{nil, &tr},
+	})
+	assertNotErr(t, qf.ToSQL(tx, qsql.Table("test")))
+}
+
+func TestQFrame_ReadSQLNull(t *testing.T) {
+	one, tr, fa := 1, true, false
+	dvr := MockDriver{t: t}
+	dvr.results.columns = []string{"COL1", "COL2"}
+	dvr.results.values = [][]driver.Value{
+		{nil, nil},
+		{int64(1), true},
+		{nil, false},
+	}
+	sql.Register("TestReadSQLNull", dvr)
+	db, _ := sql.Open("TestReadSQLNull", "")
+	tx, _ := db.Begin()
+	qf := qframe.ReadSQL(tx)
+	assertNotErr(t, qf.Err)
+	expected := qframe.New(map[string]interface{}{
+		"COL1": []*int{nil, &one, nil},
+		"COL2": []*bool{nil, &tr, &fa},
+	})
+	assertEquals(t, expected, qf)
+}
+
+func TestQFrame_ToSQLTime(t *testing.T) {
+	t1 := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	dvr := MockDriver{t: t}
+	dvr.query = "INSERT INTO test (COL1) VALUES (?);"
+	dvr.args.values = [][]driver.Value{
+		{t1},
+		{nil},
+	}
+	sql.Register("TestToSQLTime", dvr)
+	db, _ := sql.Open("TestToSQLTime", "")
+	tx, _ := db.Begin()
+	qf := qframe.New(map[string]interface{}{
+		"COL1": []*time.Time{&t1, nil},
+	})
+	assertNotErr(t, qf.ToSQL(tx, qsql.Table("test")))
+}
+
+func TestQFrame_ReadSQLTime(t *testing.T) {
+	t1 := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	dvr := MockDriver{t: t}
+	dvr.results.columns = []string{"COL1"}
+	dvr.results.values = [][]driver.Value{
+		{nil},
+		{t1},
+	}
+	sql.Register("TestReadSQLTime", dvr)
+	db, _ := sql.Open("TestReadSQLTime", "")
+	tx, _ := db.Begin()
+	qf := qframe.ReadSQL(tx)
+	assertNotErr(t, qf.Err)
+	expected := qframe.New(map[string]interface{}{
+		"COL1": []*time.Time{nil, &t1},
+	})
+	assertEquals(t, expected, qf)
+}
+
 func TestQFrame_ReadSQLCoercion(t *testing.T) {
 	dvr := MockDriver{t: t}
 	dvr.results.columns = []string{"COL1", "COL2"}
@@ -196,3 +272,43 @@ func TestQFrame_ReadSQLCoercion(t *testing.T) {
 	})
 	assertEquals(t, expected, qf)
 }
+
+func TestQFrame_ToSQLDecimal(t *testing.T) {
+	dvr := MockDriver{t: t}
+	dvr.query = "INSERT INTO test (COL1) VALUES (?);"
+	dvr.args.values = [][]driver.Value{
+		{"12.50"},
+		{nil},
+		{"0.05"},
+	}
+	sql.Register("TestToSQLDecimal", dvr)
+	db, _ := sql.Open("TestToSQLDecimal", "")
+	tx, _ := db.Begin()
+	qf := qframe.New(map[string]interface{}{
+		"COL1": decimals("12.5", "", "0.05"),
+	})
+	assertNotErr(t, qf.ToSQL(tx, qsql.Table("test")))
+}
+
+func TestQFrame_ReadSQLDecimal(t *testing.T) {
+	dvr := MockDriver{t: t}
+	dvr.results.columns = []string{"COL1"}
+	dvr.results.values = [][]driver.Value{
+		{nil},
+		{[]uint8("0.10")},
+		{int64(2)},
+	}
+	sql.Register("TestReadSQLDecimal", dvr)
+	db, _ := sql.Open("TestReadSQLDecimal", "")
+	tx, _ := db.Begin()
+	qf := qframe.ReadSQL(tx, qsql.Coerce(qsql.CoercePair{Column: "COL1", Type: qsql.TextToDecimal}))
+	assertNotErr(t, qf.Err)
+	expected := qframe.New(map[string]interface{}{
+		"COL1": decimals("", "0.10", "2"),
+	})
+	assertEquals(t, expected, qf)
+
+	v, err := qf.DecimalView("COL1")
+	assertNotErr(t, err)
+	assertTrue(t, v.Scale() == 2)
+}
@@ -10,14 +10,23 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/tobgu/qframe"
 	"github.com/tobgu/qframe/aggregation"
+	"github.com/tobgu/qframe/config/cast"
+	"github.com/tobgu/qframe/config/concat"
 	"github.com/tobgu/qframe/config/csv"
 	"github.com/tobgu/qframe/config/eval"
 	"github.com/tobgu/qframe/config/groupby"
+	"github.com/tobgu/qframe/config/interpolate"
+	"github.com/tobgu/qframe/config/join"
 	"github.com/tobgu/qframe/config/newqf"
+	"github.com/tobgu/qframe/config/sample"
+	"github.com/tobgu/qframe/decimal"
+	"github.com/tobgu/qframe/function"
 	"github.com/tobgu/qframe/types"
+	"github.com/tobgu/qframe/window"
 	"io"
 	"log"
 )
@@ -155,6 +164,7 @@ func TestQFrame_FilterColConstNull(t *testing.T) {
 	}
 
 	a, b := "a", "b"
+	one, two := 1, 2
 	table := []struct {
 		name   string
 		input  interface{}
@@ -164,6 +174,7 @@ func TestQFrame_FilterColConstNull(t *testing.T) {
 		{name: "string", input: []*string{&a, &b, nil}, arg: "b"},
 		{name: "enum", input: []*string{&a, &b, nil}, arg: "b", isEnum: true},
 		{name: "float", input: []float64{1.0, 2.0, math.NaN()}, arg: 2.0},
+		{name: "int", input: []*int{&one, &two, nil}, arg: 2},
 	}
 
 	for _, comp := range comparisons {
@@ -198,12 +209,14 @@ func TestQFrame_FilterColColNull(t *testing.T) {
 	}
 
 	a, b := "a", "b"
+	one, two := 1, 2
 	table := []struct {
 		name      string
 		inputCol1 interface{}
 		inputCol2 interface{}
 		isEnum    bool
 	}{
+		{name: "int", inputCol1: []*int{&one, &two, nil, nil}, inputCol2: []*int{&one, nil, nil, &two}},
 		{name: "string", inputCol1: []*string{&a, &b, nil, nil}, inputCol2: []*string{&a, nil, nil, &b}},
 		{name: "enum", inputCol1: []*string{&a, &b, nil, nil}, inputCol2: []*string{&a, nil, nil, &b}, isEnum: true},
 		{name: "enum", inputCol1: []float64{1.0, 2.0, math.NaN(), math.NaN()}, inputCol2: []float64{1.0, math.NaN(), math.NaN(), 2.0}},
@@ -231,6 +244,8 @@ func TestQFrame_FilterColColNull(t *testing.T) {
 
 func TestQFrame_FilterIsNull(t *testing.T) {
 	a, b := "a", "b"
+	one, two, tr, fa := 1, 2, true, false
+	t1 := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
 	table := []struct {
 		input     interface{}
 		expected  interface{}
@@ -248,6 +263,13 @@ func TestQFrame_FilterIsNull(t *testing.T) {
 		{operation: "isnotnull", input: []float64{1, math.NaN(), 2}, expected: []float64{1, 2}},
 		{operation: "isnull", input: []int{1, 2, 3}, expected: []int{}},
 		{operation: "isnotnull", input: []int{1, 2, 3}, expected: []int{1, 2, 3}},
+		{operation: "isnull", input: []*int{&one, nil, &two}, expected: []*int{nil}},
+		{operation: "isnotnull", input: []*int{&one, nil, &two}, expected: []int{1, 2}},
+		{operation: "isnull", input: []*bool{&tr, nil, &fa}, expected: []*bool{nil}},
+		{operation: "isnotnull", input: []*bool{&tr, nil, &fa}, expected: []bool{true, false}},
+		{operation: "isnotnull", input: []*bool{&tr, nil, &fa}, expected: []*bool{nil}, inverse: true},
+		{operation: "isnull", input: []*time.Time{&t1, nil}, expected: []*time.Time{nil}},
+		{operation: "isnotnull", input: []*time.Time{&t1, nil}, expected: []time.Time{t1}},
 	}
 
 	for _, tc := range table {
@@ -457,6 +479,20 @@ func TestQFrame_SortNull(t *testing.T) {
 		"COL1": []float64{1.0, math.NaN(), -1.0, math.NaN()},
 	}
 
+	one, minusOne, tr, fa := 1, -1, true, false
+	intIn := map[string]interface{}{
+		"COL1": []*int{&one, nil, &minusOne, nil},
+	}
+
+	boolIn := map[string]interface{}{
+		"COL1": []*bool{&tr, nil, &fa},
+	}
+
+	t1, t2 := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)
+	timeIn := map[string]interface{}{
+		"COL1": []*time.Time{&t2, nil, &t1},
+	}
+
 	table := []struct {
 		in       map[string]interface{}
 		orders   []qframe.Order
@@ -504,6 +540,55 @@ func TestQFrame_SortNull(t *testing.T) {
 				"COL1": []float64{-1.0, 1.0, math.NaN(), math.NaN()},
 			},
 		},
+		{
+			intIn,
+			[]qframe.Order{{Column: "COL1"}},
+			map[string]interface{}{
+				"COL1": []*int{nil, nil, &minusOne, &one},
+			},
+		},
+		{
+			intIn,
+			[]qframe.Order{{Column: "COL1", Reverse: true}},
+			map[string]interface{}{
+				"COL1": []*int{&one, &minusOne, nil, nil},
+			},
+		},
+		{
+			intIn,
+			[]qframe.Order{{Column: "COL1", NullLast: true}},
+			map[string]interface{}{
+				"COL1": []*int{&minusOne, &one, nil, nil},
+			},
+		},
+		{
+			boolIn,
+			[]qframe.Order{{Column: "COL1"}},
+			map[string]interface{}{
+				"COL1": []*bool{nil, &fa, &tr},
+			},
+		},
+		{
+			boolIn,
+			[]qframe.Order{{Column: "COL1", NullLast: true}},
+			map[string]interface{}{
+				"COL1": []*bool{&fa, &tr, nil},
+			},
+		},
+		{
+			timeIn,
+			[]qframe.Order{{Column: "COL1"}},
+			map[string]interface{}{
+				"COL1": []*time.Time{nil, &t1, &t2},
+			},
+		},
+		{
+			timeIn,
+			[]qframe.Order{{Column: "COL1", Reverse: true}},
+			map[string]interface{}{
+				"COL1": []*time.Time{&t2, &t1, nil},
+			},
+		},
 	}
 
 	for i, tc := range table {
@@ -542,6 +627,173 @@ func TestQFrame_SortStability(t *testing.T) {
 	}
 }
 
+func TestQFrame_TopK(t *testing.T) {
+	a := qframe.New(map[string]interface{}{
+		"COL1": []int{5, 2, 8, 1, 9, 3, 7, 0, 6, 4, 12, 15, 11, 14, 10, 13},
+		"COL2": []int{1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1},
+	})
+
+	table := []struct {
+		k        int
+		orders   []qframe.Order
+		expected []int
+	}{
+		{k: 3, orders: []qframe.Order{{Column: "COL1"}}, expected: []int{0, 1, 2}},
+		{k: 4, orders: []qframe.Order{{Column: "COL1", Reverse: true}}, expected: []int{15, 14, 13, 12}},
+		{k: 3, orders: []qframe.Order{{Column: "COL2", Reverse: true}, {Column: "COL1"}}, expected: []int{6, 7, 8}},
+		{k: 0, orders: []qframe.Order{{Column: "COL1"}}, expected: []int{}},
+		{k: 20, orders: []qframe.Order{{Column: "COL1"}}, expected: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}},
+		{k: 2, orders: nil, expected: []int{5, 2}},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("TopK %d", i), func(t *testing.T) {
+			b := a.TopK(tc.k, tc.orders...)
+			assertEquals(t, qframe.New(map[string]interface{}{"COL1": tc.expected}), b.Select("COL1"))
+		})
+	}
+
+	t.Run("Same result as sort followed by slice", func(t *testing.T) {
+		orders := []qframe.Order{{Column: "COL2"}, {Column: "COL1", Reverse: true}}
+		sorted := a.Sort(orders...)
+		assertEquals(t, sorted.Slice(0, 5), a.TopK(5, orders...))
+	})
+
+	t.Run("Unknown column", func(t *testing.T) {
+		assertErr(t, a.TopK(1, qframe.Order{Column: "COL3"}).Err, "unknown column")
+	})
+
+	t.Run("Negative k", func(t *testing.T) {
+		assertErr(t, a.TopK(-1, qframe.Order{Column: "COL1"}).Err, "non negative")
+	})
+}
+
+func TestQFrame_Sample(t *testing.T) {
+	a := qframe.New(map[string]interface{}{
+		"COL1": []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		"COL2": []string{"a", "a", "a", "a", "a", "a", "b", "b", "b", "b"},
+	})
+
+	distinctCount := func(f qframe.QFrame) int {
+		return f.Distinct(groupby.Columns("COL1")).Len()
+	}
+
+	t.Run("Without replacement", func(t *testing.T) {
+		out := a.Sample(5, sample.Seed(1))
+		assertNotErr(t, out.Err)
+		assertTrue(t, out.Len() == 5)
+		assertTrue(t, distinctCount(out) == 5)
+		assertEquals(t, out, a.Sample(5, sample.Seed(1)))
+	})
+
+	t.Run("All rows", func(t *testing.T) {
+		out := a.Sample(10, sample.Seed(2))
+		assertEquals(t, a, out.Sort(qframe.Order{Column: "COL1"}))
+	})
+
+	t.Run("With replacement", func(t *testing.T) {
+		out := a.Sample(100, sample.Replace(true), sample.Seed(3))
+		assertNotErr(t, out.Err)
+		assertTrue(t, out.Len() == 100)
+		assertTrue(t, distinctCount(out) <= 10)
+	})
+
+	t.Run("Fraction", func(t *testing.T) {
+		out := a.Sample(0, sample.Fraction(0.34), sample.Seed(4))
+		assertNotErr(t, out.Err)
+		assertTrue(t, out.Len() == 3)
+	})
+
+	t.Run("Stratified", func(t *testing.T) {
+		out := a.Sample(2, sample.GroupBy("COL2"), sample.Seed(5))
+		assertNotErr(t, out.Err)
+		counts := out.GroupBy(groupby.Columns("COL2")).Aggregate(qframe.Aggregation{Fn: "count", Column: "COL1"})
+		expected := qframe.New(map[string]interface{}{"COL2": []string{"a", "b"}, "COL1": []int{2, 2}}, newqf.ColumnOrder("COL2", "COL1"))
+		assertEquals(t, expected, counts.Sort(qframe.Order{Column: "COL2"}))
+
+		out = a.Sample(0, sample.GroupBy("COL2"), sample.Fraction(0.5), sample.Seed(5))
+		assertTrue(t, out.Len() == 5)
+	})
+
+	t.Run("Stratified with seed is reproducible", func(t *testing.T) {
+		out := a.Sample(2, sample.GroupBy("COL2"), sample.Seed(5))
+		expected := qframe.New(map[string]interface{}{
+			"COL1": []int{0, 2, 7, 8},
+			"COL2": []string{"a", "a", "b", "b"},
+		})
+		assertEquals(t, expected, out)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		assertErr(t, a.Sample(11).Err, "without replacement")
+		assertErr(t, a.Sample(5, sample.GroupBy("COL2")).Err, "without replacement")
+		assertErr(t, a.Sample(-1).Err, "non negative")
+		assertErr(t, a.Sample(0, sample.Fraction(-0.5)).Err, "non negative")
+		assertErr(t, a.Sample(1, sample.GroupBy("COL3")).Err, "unknown column")
+	})
+}
+
+func TestQFrame_Shuffle(t *testing.T) {
+	a := qframe.New(map[string]interface{}{"COL1": []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}})
+	out := a.Shuffle(1)
+	assertEquals(t, out, a.Shuffle(1))
+	assertEquals(t, a, out.Sort(qframe.Order{Column: "COL1"}))
+
+	view, err := out.IntView("COL1")
+	assertNotErr(t, err)
+	inOrder := true
+	for i := 0; i < view.Len(); i++ {
+		inOrder = inOrder && view.ItemAt(i) == i
+	}
+	assertTrue(t, !inOrder)
+}
+
+func TestQFrame_Describe(t *testing.T) {
+	a, b, c, tr := "a", "b", "c", "true"
+	one, two, three, four := 1, 2, 3, 4
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	in := qframe.New(map[string]interface{}{
+		"INT":   []*int{&one, &two, nil, &four, &four},
+		"FLOAT": []float64{0.5, math.NaN(), 1.5, 2.5, 3.5},
+		"STR":   []*string{&b, &a, &b, nil, &c},
+		"ENUM":  []*string{&a, &a, &b, &b, nil},
+		"BOOL":  []bool{true, false, true, true, false},
+		"DEC":   decimals("1.5", "2.5", "", "", ""),
+		"TIME":  []time.Time{tm, tm, tm.Add(time.Hour), tm, tm},
+		"I8":    []int8{1, 2, 3, 4, 5},
+	}, newqf.Enums(map[string][]string{"ENUM": {"b", "a"}}),
+		newqf.ColumnOrder("INT", "FLOAT", "STR", "ENUM", "BOOL", "DEC", "TIME", "I8"))
+
+	out := in.Describe()
+	assertNotErr(t, out.Err)
+
+	nan := math.NaN()
+	expected := qframe.New(map[string]interface{}{
+		"column":         []string{"INT", "FLOAT", "STR", "ENUM", "BOOL", "DEC", "TIME", "I8"},
+		"type":           []string{"int", "float", "string", "enum", "bool", "decimal", "time", "int8"},
+		"count":          []int{4, 4, 4, 4, 5, 2, 5, 5},
+		"null_count":     []int{1, 1, 1, 1, 0, 3, 0, 0},
+		"distinct_count": []int{3, 4, 3, 2, 2, 2, 2, 5},
+		"min":            []float64{1, 0.5, nan, nan, nan, 1.5, nan, 1},
+		"max":            []float64{4, 3.5, nan, nan, nan, 2.5, nan, 5},
+		"mean":           []float64{2.75, 2, nan, nan, nan, 2, nan, 3},
+		"std":            []float64{1.5, math.Sqrt(5.0 / 3), nan, nan, nan, math.Sqrt(0.5), nan, math.Sqrt(2.5)},
+		"25%":            []float64{1.75, 1.25, nan, nan, nan, 1.75, nan, 2},
+		"50%":            []float64{3, 2, nan, nan, nan, 2, nan, 3},
+		"75%":            []float64{4, 2.75, nan, nan, nan, 2.25, nan, 4},
+		"top":            []*string{nil, nil, &b, &b, &tr, nil, nil, nil},
+		"freq":           []*int{nil, nil, &two, &two, &three, nil, nil, nil},
+	}, newqf.ColumnOrder("column", "type", "count", "null_count", "distinct_count",
+		"min", "max", "mean", "std", "25%", "50%", "75%", "top", "freq"))
+	assertEquals(t, expected, out)
+
+	t.Run("Empty QFrame", func(t *testing.T) {
+		out := qframe.New(map[string]interface{}{"COL1": []int{}}).Describe()
+		assertNotErr(t, out.Err)
+		assertTrue(t, out.Len() == 1)
+	})
+}
+
 func TestQFrame_Distinct(t *testing.T) {
 	table := []struct {
 		input    map[string]interface{}
@@ -674,6 +926,358 @@ func TestQFrame_GroupByAggregate(t *testing.T) {
 	}
 }
 
+func TestQFrame_AggregateBuiltIns(t *testing.T) {
+	nan := math.NaN()
+	ints := []int{4, 1, 3, 1}
+	floats := []float64{4, nan, 1, 3, 1}
+	table := []struct {
+		fn       string
+		input    interface{}
+		expected interface{}
+	}{
+		{fn: "sum", input: ints, expected: []int{9}},
+		{fn: "min", input: ints, expected: []int{1}},
+		{fn: "max", input: ints, expected: []int{4}},
+		{fn: "first", input: ints, expected: []int{4}},
+		{fn: "last", input: ints, expected: []int{1}},
+		{fn: "avg", input: ints, expected: []float64{2.25}},
+		{fn: "median", input: ints, expected: []float64{2}},
+		{fn: "quantile(0.75)", input: ints, expected: []float64{3.25}},
+		{fn: "quantile(1)", input: ints, expected: []float64{4}},
+		{fn: "var", input: ints, expected: []float64{2.25}},
+		{fn: "std", input: ints, expected: []float64{1.5}},
+		{fn: "count_distinct", input: ints, expected: []int{3}},
+		{fn: "nunique", input: ints, expected: []int{3}},
+		{fn: "sum", input: floats, expected: []float64{nan}},
+		{fn: "avg", input: floats, expected: []float64{nan}},
+		{fn: "min", input: floats, expected: []float64{nan}},
+		{fn: "max", input: floats, expected: []float64{nan}},
+		{fn: "first", input: floats, expected: []float64{4}},
+		{fn: "last", input: floats, expected: []float64{1}},
+		{fn: "median", input: floats, expected: []float64{nan}},
+		{fn: "quantile(0.75)", input: floats, expected: []float64{nan}},
+		{fn: "var", input: floats, expected: []float64{nan}},
+		{fn: "std", input: floats, expected: []float64{nan}},
+		{fn: "count_distinct", input: floats, expected: []int{4}},
+		{fn: "nunique", input: floats, expected: []int{3}},
+		{fn: "nansum", input: floats, expected: []float64{9}},
+		{fn: "nanavg", input: floats, expected: []float64{2.25}},
+		{fn: "nanmin", input: floats, expected: []float64{1}},
+		{fn: "nanmax", input: floats, expected: []float64{4}},
+		{fn: "nanfirst", input: []float64{nan, 2, 3}, expected: []float64{2}},
+		{fn: "nanlast", input: []float64{1, 2, nan}, expected: []float64{2}},
+		{fn: "nanmedian", input: floats, expected: []float64{2}},
+		{fn: "nanquantile(0.75)", input: floats, expected: []float64{3.25}},
+		{fn: "nanvar", input: floats, expected: []float64{2.25}},
+		{fn: "nanstd", input: floats, expected: []float64{1.5}},
+		{fn: "nansum", input: []float64{nan, nan}, expected: []float64{nan}},
+		{fn: "std", input: []float64{1}, expected: []float64{nan}},
+	}
+
+	for _, tc := range table {
+		t.Run(fmt.Sprintf("%s %T", tc.fn, tc.input), func(t *testing.T) {
+			in := qframe.New(map[string]interface{}{"COL1": tc.input})
+			out := in.GroupBy().Aggregate(qframe.Aggregation{Fn: tc.fn, Column: "COL1"})
+			assertNotErr(t, out.Err)
+			assertEquals(t, qframe.New(map[string]interface{}{"COL1": tc.expected}), out)
+
+			// The input must be left untouched by aggregations that sort the values
+			assertEquals(t, qframe.New(map[string]interface{}{"COL1": tc.input}), in)
+		})
+	}
+}
+
+func TestQFrame_AggregateResultTypes(t *testing.T) {
+	table := []struct {
+		name     string
+		input    interface{}
+		enum     bool
+		fn       interface{}
+		expected interface{}
+	}{
+		{
+			name:     "int to float",
+			input:    []int{1, 2, 2, 4, 5, 7},
+			fn:       func(x []int) float64 { return float64(len(x)) / 2 },
+			expected: []float64{1.5, 1.5}},
+		{
+			name:     "int to string",
+			input:    []int{1, 2, 2, 4, 5, 7},
+			fn:       func(x []int) *string { s := fmt.Sprint(x); return &s },
+			expected: []string{"[1 2 2]", "[4 5 7]"}},
+		{
+			name:     "float to bool",
+			input:    []float64{1, 2, 2, 4, 5, 7},
+			fn:       func(x []float64) bool { return x[0] > 2 },
+			expected: []bool{false, true}},
+		{
+			name:     "bool to int",
+			input:    []bool{true, false, true, true, true, false},
+			fn:       func(x []bool) int { return len(x) },
+			expected: []int{3, 3}},
+		{
+			name:     "string to int",
+			input:    []string{"a", "bb", "ccc", "a", "b", "c"},
+			fn:       func(x []*string) int { return len(*x[1]) },
+			expected: []int{2, 1}},
+		{
+			name:     "string to float",
+			input:    []string{"a", "bb", "ccc", "a", "b", "c"},
+			fn:       func(x []*string) float64 { return float64(len(*x[2])) },
+			expected: []float64{3, 1}},
+		{
+			name:     "enum to bool",
+			input:    []string{"a", "bb", "ccc", "a", "b", "c"},
+			enum:     true,
+			fn:       func(x []*string) bool { return *x[0] == "a" },
+			expected: []bool{true, true}},
+		{
+			name:     "enum to int",
+			input:    []string{"a", "bb", "ccc", "a", "b", "c"},
+			enum:     true,
+			fn:       func(x []*string) int { return len(x) },
+			expected: []int{3, 3}},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			enums := map[string][]string{}
+			if tc.enum {
+				enums["COL2"] = nil
+			}
+
+			in := qframe.New(map[string]interface{}{"COL1": []int{0, 0, 0, 1, 1, 1}, "COL2": tc.input}, newqf.Enums(enums))
+			out := in.GroupBy(groupby.Columns("COL1")).Aggregate(qframe.Aggregation{Fn: tc.fn, Column: "COL2"})
+			assertNotErr(t, out.Err)
+
+			expected := qframe.New(map[string]interface{}{"COL1": []int{0, 1}, "COL2": tc.expected})
+			assertEquals(t, expected, out.Sort(qframe.Order{Column: "COL1"}))
+		})
+	}
+}
+
+func TestQFrame_AggregateColumns(t *testing.T) {
+	vwap := func(price []float64, volume []int) float64 {
+		sum, totalVolume := 0.0, 0
+		for i, p := range price {
+			sum += p * float64(volume[i])
+			totalVolume += volume[i]
+		}
+		return sum / float64(totalVolume)
+	}
+
+	ratio := func(a, b []int) float64 {
+		sumA, sumB := 0, 0
+		for i := range a {
+			sumA += a[i]
+			sumB += b[i]
+		}
+		return float64(sumA) / float64(sumB)
+	}
+
+	in := qframe.New(map[string]interface{}{
+		"KEY":    []string{"x", "y", "x", "y"},
+		"PRICE":  []float64{10, 1, 20, 2},
+		"VOLUME": []int{3, 1, 1, 3},
+		"A":      []int{1, 2, 3, 4},
+	}, newqf.Enums(map[string][]string{"KEY": nil}))
+
+	out := in.GroupBy(groupby.Columns("KEY")).Aggregate(
+		qframe.Aggregation{Fn: vwap, Columns: []string{"PRICE", "VOLUME"}, As: "VWAP"},
+		qframe.Aggregation{Fn: ratio, Columns: []string{"A", "VOLUME"}, As: "RATIO"},
+		qframe.Aggregation{Fn: "sum", Column: "VOLUME"},
+		qframe.Aggregation{
+			Fn:      func(key []*string, a []int) *string { s := fmt.Sprint(*key[0], a); return &s },
+			Columns: []string{"KEY", "A"},
+			As:      "DESC"})
+	assertNotErr(t, out.Err)
+
+	expected := qframe.New(map[string]interface{}{
+		"KEY":    []string{"x", "y"},
+		"VWAP":   []float64{12.5, 1.75},
+		"RATIO":  []float64{1, 1.5},
+		"VOLUME": []int{4, 4},
+		"DESC":   []string{"x[1 3]", "y[2 4]"},
+	}, newqf.Enums(map[string][]string{"KEY": nil}), newqf.ColumnOrder("KEY", "VWAP", "RATIO", "VOLUME", "DESC"))
+	assertEquals(t, expected, out.Sort(qframe.Order{Column: "KEY"}))
+}
+
+func TestQFrame_AggregateColumnsNarrowTypes(t *testing.T) {
+	vwap := func(price []float32, volume []uint16) float64 {
+		sum, totalVolume := 0.0, 0
+		for i, p := range price {
+			sum += float64(p) * float64(volume[i])
+			totalVolume += int(volume[i])
+		}
+		return sum / float64(totalVolume)
+	}
+
+	in := qframe.New(map[string]interface{}{
+		"KEY":    []int8{1, 2, 1, 2},
+		"PRICE":  []float32{10, 1, 20, 2},
+		"VOLUME": []uint16{3, 1, 1, 3},
+	})
+
+	out := in.GroupBy(groupby.Columns("KEY")).Aggregate(
+		qframe.Aggregation{Fn: vwap, Columns: []string{"PRICE", "VOLUME"}, As: "VWAP"},
+		qframe.Aggregation{
+			Fn:      func(key []int8, volume []uint16) int { return int(key[0]) * len(volume) },
+			Columns: []string{"KEY", "VOLUME"},
+			As:      "KEYS"})
+	assertNotErr(t, out.Err)
+
+	expected := qframe.New(map[string]interface{}{
+		"KEY":  []int8{1, 2},
+		"VWAP": []float64{12.5, 1.75},
+		"KEYS": []int{2, 4},
+	}, newqf.ColumnOrder("KEY", "VWAP", "KEYS"))
+	assertEquals(t, expected, out.Sort(qframe.Order{Column: "KEY"}))
+}
+
+func TestQFrame_AggregateColumnsTime(t *testing.T) {
+	tm := func(day int) time.Time { return time.Date(2020, 1, day, 0, 0, 0, 0, time.UTC) }
+	t3, t4, t5 := tm(3), tm(4), tm(5)
+	activeHours := func(ts []time.Time, active []bool) float64 {
+		var first, last time.Time
+		for i, t := range ts {
+			if !active[i] {
+				continue
+			}
+			if first.IsZero() || t.Before(first) {
+				first = t
+			}
+			if t.After(last) {
+				last = t
+			}
+		}
+		return last.Sub(first).Hours()
+	}
+
+	in := qframe.New(map[string]interface{}{
+		"KEY":    []int{1, 1, 1, 2, 2, 2},
+		"T":      []*time.Time{&t3, nil, &t4, &t3, &t4, &t5},
+		"ACTIVE": []bool{true, true, true, false, true, true},
+	})
+
+	out := in.GroupBy(groupby.Columns("KEY")).Aggregate(
+		qframe.Aggregation{Fn: activeHours, Columns: []string{"T", "ACTIVE"}, As: "HOURS"})
+	assertNotErr(t, out.Err)
+
+	expected := qframe.New(map[string]interface{}{
+		"KEY":   []int{1, 2},
+		"HOURS": []float64{24, 24},
+	}, newqf.ColumnOrder("KEY", "HOURS"))
+	assertEquals(t, expected, out.Sort(qframe.Order{Column: "KEY"}))
+}
+
+func TestQFrame_AggregateColumnsDecimal(t *testing.T) {
+	weightedPrice := func(price []decimal.Decimal, volume []int) *string {
+		sum, totalVolume := decimal.New(0, 2), 0
+		for i, p := range price {
+			for j := 0; j < volume[i]; j++ {
+				sum, _ = sum.Add(p)
+			}
+			totalVolume += volume[i]
+		}
+		s := fmt.Sprintf("%s/%d", sum, totalVolume)
+		return &s
+	}
+
+	in := qframe.New(map[string]interface{}{
+		"KEY":    []int{1, 1, 1, 2},
+		"PRICE":  decimals("1.25", "", "2", "0.5"),
+		"VOLUME": []int{2, 5, 1, 3},
+	})
+
+	out := in.GroupBy(groupby.Columns("KEY")).Aggregate(
+		qframe.Aggregation{Fn: weightedPrice, Columns: []string{"PRICE", "VOLUME"}, As: "WEIGHTED"})
+	assertNotErr(t, out.Err)
+
+	expected := qframe.New(map[string]interface{}{
+		"KEY":      []int{1, 2},
+		"WEIGHTED": []string{"4.50/3", "1.50/3"},
+	}, newqf.ColumnOrder("KEY", "WEIGHTED"))
+	assertEquals(t, expected, out.Sort(qframe.Order{Column: "KEY"}))
+}
+
+func TestQFrame_AggregateColumnsErrors(t *testing.T) {
+	in := qframe.New(map[string]interface{}{
+		"KEY": []int{1, 1, 2},
+		"A":   []int{1, 2, 3},
+		"B":   []float64{1, 2, 3},
+	})
+
+	fn := func(a []int, b []float64) float64 { return 0 }
+	table := []struct {
+		name string
+		agg  qframe.Aggregation
+		err  string
+	}{
+		{name: "missing As", agg: qframe.Aggregation{Fn: fn, Columns: []string{"A", "B"}}, err: "As must be set"},
+		{name: "both Column and Columns", agg: qframe.Aggregation{Fn: fn, Column: "A", Columns: []string{"A", "B"}, As: "X"}, err: "must not both be set"},
+		{name: "unknown column", agg: qframe.Aggregation{Fn: fn, Columns: []string{"A", "C"}, As: "X"}, err: "unknown column"},
+		{name: "wrong number of columns", agg: qframe.Aggregation{Fn: fn, Columns: []string{"A"}, As: "X"}, err: "invalid aggregation function type for 1 columns"},
+		{name: "wrong argument type", agg: qframe.Aggregation{Fn: fn, Columns: []string{"B", "A"}, As: "X"}, err: "invalid argument type for column B"},
+		{name: "not a function", agg: qframe.Aggregation{Fn: "sum", Columns: []string{"A", "B"}, As: "X"}, err: "invalid aggregation function type"},
+		{name: "unsupported result type", agg: qframe.Aggregation{Fn: func(a []int, b []float64) uint { return 0 }, Columns: []string{"A", "B"}, As: "X"}, err: "unexpected type of new columns"},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			out := in.GroupBy(groupby.Columns("KEY")).Aggregate(tc.agg)
+			assertErr(t, out.Err, tc.err)
+		})
+	}
+}
+
+func TestQFrame_AggregateStringBuiltIns(t *testing.T) {
+	a, b, c := "a", "b", "c"
+	input := []*string{&b, &a, nil, &c, &a}
+	ties := []*string{&b, &a, &a, &b}
+	enumValues := []string{"c", "b", "a"}
+	table := []struct {
+		fn       string
+		input    []*string
+		enum     bool
+		expected interface{}
+	}{
+		{fn: "min", input: input, expected: []string{"a"}},
+		{fn: "max", input: input, expected: []string{"c"}},
+		{fn: "first", input: input, expected: []string{"b"}},
+		{fn: "last", input: input, expected: []string{"a"}},
+		{fn: "mode", input: input, expected: []string{"a"}},
+		{fn: "mode", input: ties, expected: []string{"a"}},
+		{fn: "mode", input: []*string{nil}, expected: []*string{nil}},
+		{fn: "count_distinct", input: input, expected: []int{4}},
+		{fn: "join", input: input, expected: []string{"b,a,c,a"}},
+		{fn: "join(; )", input: input, expected: []string{"b; a; c; a"}},
+		{fn: "min", input: input, enum: true, expected: []string{"c"}},
+		{fn: "min", input: []*string{nil}, enum: true, expected: []*string{nil}},
+		{fn: "max", input: input, enum: true, expected: []string{"a"}},
+		{fn: "first", input: input, enum: true, expected: []string{"b"}},
+		{fn: "last", input: input, enum: true, expected: []string{"a"}},
+		{fn: "mode", input: input, enum: true, expected: []string{"a"}},
+		{fn: "mode", input: ties, enum: true, expected: []string{"b"}},
+		{fn: "count_distinct", input: input, enum: true, expected: []int{4}},
+		{fn: "join", input: input, enum: true, expected: []string{"b,a,c,a"}},
+		{fn: "join(-)", input: input, enum: true, expected: []string{"b-a-c-a"}},
+	}
+
+	for _, tc := range table {
+		t.Run(fmt.Sprintf("%s enum %t", tc.fn, tc.enum), func(t *testing.T) {
+			enums := map[string][]string{}
+			if tc.enum {
+				enums["COL1"] = enumValues
+			}
+
+			in := qframe.New(map[string]interface{}{"COL1": tc.input}, newqf.Enums(enums))
+			out := in.GroupBy().Aggregate(qframe.Aggregation{Fn: tc.fn, Column: "COL1"})
+			assertNotErr(t, out.Err)
+			assertEquals(t, qframe.New(map[string]interface{}{"COL1": tc.expected}), out)
+		})
+	}
+}
+
 func TestQFrame_RollingWindow(t *testing.T) {
 	sum := func(col []int) int {
 		result := 0
@@ -683,6 +1287,7 @@ func TestQFrame_RollingWindow(t *testing.T) {
 		return result
 	}
 
+	ab, bc := "a,b", "b,c"
 	table := []struct {
 		name     string
 		input    map[string]interface{}
@@ -696,29 +1301,269 @@ func TestQFrame_RollingWindow(t *testing.T) {
 			expected: map[string]interface{}{"destination": []int{1, 2, 3}},
 			fn:       sum,
 		},
+		{
+			name:     "center window without pad value",
+			input:    map[string]interface{}{"source": []int{1, 2, 3, 4, 5}},
+			expected: map[string]interface{}{"destination": []int{3, 6, 9, 12, 9}},
+			fn:       sum,
+			configs:  []rolling.ConfigFunc{rolling.WindowSize(3)},
+		},
+		{
+			name:     "even sized center window",
+			input:    map[string]interface{}{"source": []int{1, 2, 3, 4, 5}},
+			expected: map[string]interface{}{"destination": []int{1, 3, 5, 7, 9}},
+			fn:       sum,
+			configs:  []rolling.ConfigFunc{rolling.WindowSize(2)},
+		},
+		{
+			name:     "end window with pad value",
+			input:    map[string]interface{}{"source": []int{1, 2, 3, 4, 5}},
+			expected: map[string]interface{}{"destination": []int{0, 0, 6, 9, 12}},
+			fn:       sum,
+			configs:  []rolling.ConfigFunc{rolling.WindowSize(3), rolling.Position("end"), rolling.PadValue(0)},
+		},
+		{
+			name:     "start window with pad value",
+			input:    map[string]interface{}{"source": []int{1, 2, 3, 4, 5}},
+			expected: map[string]interface{}{"destination": []int{6, 9, 12, -1, -1}},
+			fn:       sum,
+			configs:  []rolling.ConfigFunc{rolling.WindowSize(3), rolling.Position("start"), rolling.PadValue(-1)},
+		},
+		{
+			name:     "built in function",
+			input:    map[string]interface{}{"source": []float64{1, 2, 3, 4}},
+			expected: map[string]interface{}{"destination": []float64{1, 1.5, 2.5, 3.5}},
+			fn:       "avg",
+			configs:  []rolling.ConfigFunc{rolling.WindowSize(2), rolling.Position("end")},
+		},
+		{
+			name:     "interval function start",
+			input:    map[string]interface{}{"source": []int{1, 1, 1, 1, 1, 1}, "ts": []int{0, 1, 2, 5, 6, 10}},
+			expected: map[string]interface{}{"destination": []int{3, 2, 1, 2, 1, 1}},
+			fn:       sum,
+			configs: []rolling.ConfigFunc{
+				rolling.IntervalFunction("ts", func(start, end int) bool { return end < start+3 }),
+				rolling.Position("start")},
+		},
+		{
+			name:     "interval function without position",
+			input:    map[string]interface{}{"source": []int{1, 1, 1, 1, 1, 1}, "ts": []int{0, 1, 2, 5, 6, 10}},
+			expected: map[string]interface{}{"destination": []int{3, 2, 1, 2, 1, 1}},
+			fn:       sum,
+			configs: []rolling.ConfigFunc{
+				rolling.IntervalFunction("ts", func(start, end int) bool { return end < start+3 })},
+		},
+		{
+			name:     "interval function end",
+			input:    map[string]interface{}{"source": []int{1, 1, 1, 1, 1, 1}, "ts": []int{0, 1, 2, 5, 6, 10}},
+			expected: map[string]interface{}{"destination": []int{1, 2, 3, 1, 2, 1}},
+			fn:       sum,
+			configs: []rolling.ConfigFunc{
+				rolling.IntervalFunction("ts", func(start, end int) bool { return end < start+3 }),
+				rolling.Position("end")},
+		},
+		{
+			name:     "string column",
+			input:    map[string]interface{}{"source": []string{"a", "b", "c"}},
+			expected: map[string]interface{}{"destination": []string{"a", "a,b", "b,c"}},
+			fn:       aggregation.StrJoin(","),
+			configs:  []rolling.ConfigFunc{rolling.WindowSize(2), rolling.Position("end")},
+		},
+		{
+			name:     "string column with pad value",
+			input:    map[string]interface{}{"source": []string{"a", "b", "c"}},
+			expected: map[string]interface{}{"destination": []string{"a,b", "b,c", "-"}},
+			fn:       aggregation.StrJoin(","),
+			configs:  []rolling.ConfigFunc{rolling.WindowSize(2), rolling.Position("start"), rolling.PadValue("-")},
+		},
+		{
+			name:     "string column with null pad value",
+			input:    map[string]interface{}{"source": []string{"a", "b", "c"}},
+			expected: map[string]interface{}{"destination": []*string{nil, &ab, &bc}},
+			fn:       aggregation.StrJoin(","),
+			configs:  []rolling.ConfigFunc{rolling.WindowSize(2), rolling.Position("end"), rolling.PadValue((*string)(nil))},
+		},
+		{
+			name:     "string column with built in function",
+			input:    map[string]interface{}{"source": []string{"b", "a", "c"}},
+			expected: map[string]interface{}{"destination": []string{"b", "b;a", "a;c"}},
+			fn:       "join(;)",
+			configs:  []rolling.ConfigFunc{rolling.WindowSize(2), rolling.Position("end")},
+		},
+		{
+			name:     "string column with built in function returning int",
+			input:    map[string]interface{}{"source": []string{"a", "a", "b", "b"}},
+			expected: map[string]interface{}{"destination": []int{1, 2, 1, -1}},
+			fn:       "count_distinct",
+			configs:  []rolling.ConfigFunc{rolling.WindowSize(2), rolling.Position("start"), rolling.PadValue(-1)},
+		},
+		{
+			name:     "string column with interval function",
+			input:    map[string]interface{}{"source": []string{"a", "b", "c", "d"}, "key": []string{"x", "x", "y", "y"}},
+			expected: map[string]interface{}{"destination": []string{"a,b", "b", "c,d", "d"}},
+			fn:       aggregation.StrJoin(","),
+			configs: []rolling.ConfigFunc{
+				rolling.IntervalFunction("key", func(start, end *string) bool { return *start == *end }),
+				rolling.Position("start")},
+		},
 	}
 
 	for _, tc := range table {
 		t.Run(fmt.Sprintf("Rolling %s", tc.name), func(t *testing.T) {
 			in := qframe.New(tc.input)
 
-			out := in.Rolling(tc.fn, "destination", "source")
+			out := in.Rolling(tc.fn, "destination", "source", tc.configs...)
 
+			assertNotErr(t, out.Err)
 			assertEquals(t, qframe.New(tc.expected), out.Select("destination"))
 		})
 	}
 }
 
-func colNamesToOrders(colNames ...string) []qframe.Order {
-	result := make([]qframe.Order, len(colNames))
-	for i, name := range colNames {
-		result[i] = qframe.Order{Column: name}
-	}
-	return result
-}
+func TestQFrame_RollingWindowGrouped(t *testing.T) {
+	in := qframe.New(map[string]interface{}{
+		"ID":  []string{"a", "b", "a", "b", "a", "b", "c"},
+		"VAL": []float64{1, 10, 2, 20, 3, 30, 100}},
+		newqf.ColumnOrder("ID", "VAL"))
 
-func TestQFrame_Select(t *testing.T) {
-	table := []struct {
+	out := in.GroupBy(groupby.Columns("ID")).Rolling("avg", "AVG", "VAL", rolling.WindowSize(2), rolling.Position("end"))
+	assertNotErr(t, out.Err)
+
+	expected := qframe.New(map[string]interface{}{
+		"ID":  []string{"a", "b", "a", "b", "a", "b", "c"},
+		"VAL": []float64{1, 10, 2, 20, 3, 30, 100},
+		"AVG": []float64{1, 10, 1.5, 15, 2.5, 25, 100}},
+		newqf.ColumnOrder("ID", "VAL", "AVG"))
+	assertEquals(t, expected, out)
+
+	// Sorted within groups
+	out = in.GroupBy(groupby.Columns("ID")).
+		Sort(qframe.Order{Column: "VAL", Reverse: true}).
+		Rolling("sum", "SUM", "VAL", rolling.WindowSize(2), rolling.Position("end"), rolling.PadValue(0.0))
+	assertNotErr(t, out.Err)
+
+	expected = qframe.New(map[string]interface{}{
+		"ID":  []string{"a", "b", "a", "b", "a", "b", "c"},
+		"VAL": []float64{1, 10, 2, 20, 3, 30, 100},
+		"SUM": []float64{3, 30, 5, 50, 0, 0, 0}},
+		newqf.ColumnOrder("ID", "VAL", "SUM"))
+	assertEquals(t, expected, out)
+}
+
+func TestQFrame_RollingWindowEnum(t *testing.T) {
+	in := qframe.New(map[string]interface{}{
+		"ID":  []string{"a", "a", "b", "a", "b"},
+		"VAL": []string{"x", "y", "z", "y", "x"}},
+		newqf.Enums(map[string][]string{"VAL": {"x", "y", "z"}}),
+		newqf.ColumnOrder("ID", "VAL"))
+
+	out := in.GroupBy(groupby.Columns("ID")).Rolling(aggregation.StrJoin(""), "JOINED", "VAL", rolling.WindowSize(2), rolling.Position("end"))
+	assertNotErr(t, out.Err)
+
+	// The result of rolling over an enum column is a string column
+	expected := qframe.New(map[string]interface{}{
+		"ID":     []string{"a", "a", "b", "a", "b"},
+		"VAL":    []string{"x", "y", "z", "y", "x"},
+		"JOINED": []string{"x", "xy", "z", "yy", "zx"}},
+		newqf.Enums(map[string][]string{"VAL": {"x", "y", "z"}}),
+		newqf.ColumnOrder("ID", "VAL", "JOINED"))
+	assertEquals(t, expected, out)
+
+	// Built in functions use the enum order
+	out = in.GroupBy(groupby.Columns("ID")).Rolling("max", "MAX", "VAL", rolling.WindowSize(2), rolling.Position("end"))
+	assertNotErr(t, out.Err)
+	assertEquals(t, qframe.New(map[string]interface{}{"MAX": []string{"x", "y", "z", "y", "z"}}), out.Select("MAX"))
+}
+
+func TestQFrame_RollingIntervalDefaultPosition(t *testing.T) {
+	in := qframe.New(map[string]interface{}{"VAL": []int{1, 2, 3, 4}, "TS": []int{0, 1, 5, 6}})
+	interval := rolling.IntervalFunction("TS", func(start, end int) bool { return end < start+2 })
+
+	out := in.Rolling("sum", "SUM", "VAL", interval)
+	assertNotErr(t, out.Err)
+	assertEquals(t, in.Rolling("sum", "SUM", "VAL", interval, rolling.Position("start")), out)
+
+	expected := qframe.New(map[string]interface{}{"SUM": []int{3, 2, 7, 4}})
+	assertEquals(t, expected, out.Select("SUM"))
+}
+
+func TestQFrame_RollingWindowErrors(t *testing.T) {
+	in := qframe.New(map[string]interface{}{
+		"INT": []int{1, 2, 3},
+		"STR": []string{"a", "b", "c"}})
+
+	table := []struct {
+		name    string
+		srcCol  string
+		fn      interface{}
+		configs []rolling.ConfigFunc
+		err     string
+	}{
+		{
+			name:    "interval function with center position",
+			srcCol:  "INT",
+			fn:      "sum",
+			configs: []rolling.ConfigFunc{rolling.IntervalFunction("INT", func(x, y int) bool { return true }), rolling.Position("center")},
+			err:     "Position must be start/end"},
+		{
+			name:    "unknown interval column",
+			srcCol:  "INT",
+			fn:      "sum",
+			configs: []rolling.ConfigFunc{rolling.IntervalFunction("FOO", func(x, y int) bool { return true }), rolling.Position("end")},
+			err:     "unknown column"},
+		{
+			name:    "invalid interval function type",
+			srcCol:  "INT",
+			fn:      "sum",
+			configs: []rolling.ConfigFunc{rolling.IntervalFunction("STR", func(x, y int) bool { return true }), rolling.Position("end")},
+			err:     "invalid interval function type"},
+		{
+			name:    "invalid pad value type",
+			srcCol:  "INT",
+			fn:      "sum",
+			configs: []rolling.ConfigFunc{rolling.PadValue(1.5)},
+			err:     "invalid pad value type"},
+		{
+			name:   "unknown built in function",
+			srcCol: "INT",
+			fn:     "foo",
+			err:    "not defined"},
+		{
+			name:   "built in function on string column",
+			srcCol: "STR",
+			fn:     "sum",
+			err:    "not defined"},
+		{
+			name:    "invalid string pad value type",
+			srcCol:  "STR",
+			fn:      aggregation.StrJoin(","),
+			configs: []rolling.ConfigFunc{rolling.PadValue(1)},
+			err:     "invalid pad value type"},
+		{
+			name:   "unknown source column",
+			srcCol: "FOO",
+			fn:     "sum",
+			err:    "unknown column"},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			out := in.Rolling(tc.fn, "DST", tc.srcCol, tc.configs...)
+			assertErr(t, out.Err, tc.err)
+		})
+	}
+}
+
+func colNamesToOrders(colNames ...string) []qframe.Order {
+	result := make([]qframe.Order, len(colNames))
+	for i, name := range colNames {
+		result[i] = qframe.Order{Column: name}
+	}
+	return result
+}
+
+func TestQFrame_Select(t *testing.T) {
+	table := []struct {
 		input      map[string]interface{}
 		expected   map[string]interface{}
 		selectCols []string
@@ -818,12 +1663,14 @@ func TestQFrame_ReadCSV(t *testing.T) {
 		1  aaa  3.25  7.0  NaN
 	*/
 	a, b, c, empty := "a", "b", "c", ""
+	one, three, tr := 1, 3, true
 	table := []struct {
 		name             string
 		inputHeaders     []string
 		inputData        string
 		emptyNull        bool
 		ignoreEmptyLines bool
+		inferNullable    bool
 		expected         map[string]interface{}
 		types            map[string]string
 		expectedErr      string
@@ -956,6 +1803,41 @@ func TestQFrame_ReadCSV(t *testing.T) {
 			emptyNull:    true,
 			expected:     map[string]interface{}{"foo": []*string{&a, nil, &c}},
 		},
+		{
+			name:         "Int and bool with empty fields",
+			inputHeaders: []string{"foo", "bar"},
+			inputData:    "1,\n,true\n3,\n",
+			expected: map[string]interface{}{
+				"foo": []float64{1, math.NaN(), 3},
+				"bar": []string{"", "true", ""}},
+		},
+		{
+			name:          "Int and bool with null values",
+			inputHeaders:  []string{"foo", "bar"},
+			inputData:     "1,\n,true\n3,\n",
+			inferNullable: true,
+			expected: map[string]interface{}{
+				"foo": []*int{&one, nil, &three},
+				"bar": []*bool{nil, &tr, nil}},
+		},
+		{
+			name:         "Explicit int and bool with null values",
+			inputHeaders: []string{"foo", "bar"},
+			inputData:    "1,\n,true\n3,\n",
+			types:        map[string]string{"foo": "int", "bar": "bool"},
+			expected: map[string]interface{}{
+				"foo": []*int{&one, nil, &three},
+				"bar": []*bool{nil, &tr, nil}},
+		},
+		{
+			name:         "Only null values",
+			inputHeaders: []string{"foo", "bar"},
+			inputData:    ",\n,\n",
+			types:        map[string]string{"bar": "bool"},
+			expected: map[string]interface{}{
+				"foo": []float64{math.NaN(), math.NaN()},
+				"bar": []*bool{nil, nil}},
+		},
 		{
 			name:         "CRLF",
 			rowDelimiter: "\r\n",
@@ -990,6 +1872,7 @@ func TestQFrame_ReadCSV(t *testing.T) {
 				csv.EmptyNull(tc.emptyNull),
 				csv.Types(tc.types),
 				csv.IgnoreEmptyLines(tc.ignoreEmptyLines),
+				csv.InferNullable(tc.inferNullable),
 				csv.Delimiter(tc.delimiter))
 			if tc.expectedErr != "" {
 				assertErr(t, out.Err, tc.expectedErr)
@@ -1077,6 +1960,46 @@ func TestQFrame_ReadCSVNoHeader(t *testing.T) {
 	assertEquals(t, expected, out)
 }
 
+func TestQFrame_ReadCSVTime(t *testing.T) {
+	t1 := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	t2 := time.Date(2019, 1, 2, 12, 30, 0, 0, time.UTC)
+
+	t.Run("Default layout", func(t *testing.T) {
+		input := "abc,def\n2019-01-01T10:00:00Z,1\n,2\n2019-01-02T14:30:00+02:00,3\n"
+		out := qframe.ReadCSV(strings.NewReader(input), csv.Types(map[string]string{"abc": "time"}))
+		assertNotErr(t, out.Err)
+
+		expected := qframe.New(map[string]interface{}{
+			"abc": []*time.Time{&t1, nil, &t2},
+			"def": []int{1, 2, 3}}, newqf.ColumnOrder("abc", "def"))
+		assertEquals(t, expected, out)
+
+		buf := new(bytes.Buffer)
+		assertNotErr(t, out.ToCSV(buf))
+		expectedCSV := "abc,def\n2019-01-01T10:00:00Z,1\n,2\n2019-01-02T12:30:00Z,3\n"
+		if buf.String() != expectedCSV {
+			t.Errorf("Unexpected CSV: %s", buf.String())
+		}
+	})
+
+	t.Run("Custom layout and location", func(t *testing.T) {
+		loc := time.FixedZone("UTC+2", 2*60*60)
+		input := "abc\n2019-01-01 12:00\n2019-01-02 14:30\n"
+		out := qframe.ReadCSV(strings.NewReader(input),
+			csv.Types(map[string]string{"abc": "time"}),
+			csv.TimeLayout("2006-01-02 15:04"),
+			csv.TimeLocation(loc))
+		assertNotErr(t, out.Err)
+		assertEquals(t, qframe.New(map[string]interface{}{"abc": []time.Time{t1, t2}}), out)
+		assertTrue(t, out.MustTimeView("abc").ItemAt(0).Location() == loc)
+	})
+
+	t.Run("Invalid time", func(t *testing.T) {
+		out := qframe.ReadCSV(strings.NewReader("abc\n2019-01-01\n"), csv.Types(map[string]string{"abc": "time"}))
+		assertErr(t, out.Err, "Create time column")
+	})
+}
+
 func TestQFrame_Enum(t *testing.T) {
 	mon, tue, wed, thu, fri, sat, sun := "mon", "tue", "wed", "thu", "fri", "sat", "sun"
 	t.Run("Applies specified order", func(t *testing.T) {
@@ -1130,9 +2053,77 @@ foo
 		assertErr(t, out.Err, "unknown enum value")
 	})
 
+	t.Run("Supports more than 255 values", func(t *testing.T) {
+		values := make([]string, 0)
+		for i := 0; i < 1000; i++ {
+			values = append(values, fmt.Sprintf("v%d", 1000-i))
+		}
+
+		// Values given in reverse order, sorting should respect the given order
+		input := []string{"v1", "v1000", "v500", "v999"}
+		out := qframe.New(
+			map[string]interface{}{"foo": input},
+			newqf.Enums(map[string][]string{"foo": values}))
+		assertNotErr(t, out.Err)
+
+		out = out.Sort(qframe.Order{Column: "foo"})
+		expected := qframe.New(
+			map[string]interface{}{"foo": []string{"v1000", "v999", "v500", "v1"}},
+			newqf.Enums(map[string][]string{"foo": values}))
+		assertEquals(t, expected, out)
+
+		out = out.Filter(qframe.Filter{Column: "foo", Comparator: ">", Arg: "v999"})
+		assertEquals(t, qframe.New(
+			map[string]interface{}{"foo": []string{"v500", "v1"}},
+			newqf.Enums(map[string][]string{"foo": values})), out)
+	})
+
+	t.Run("Switches to wide representation when values are added", func(t *testing.T) {
+		input := make([]*string, 0)
+		for i := 0; i < 300; i++ {
+			s := strconv.Itoa(i)
+			input = append(input, &s, nil)
+		}
+
+		out := qframe.New(
+			map[string]interface{}{"foo": input},
+			newqf.Enums(map[string][]string{"foo": nil}))
+		assertNotErr(t, out.Err)
+
+		view := out.MustEnumView("foo")
+		assertTrue(t, *view.ItemAt(0) == "0")
+		assertTrue(t, view.ItemAt(509) == nil)
+		assertTrue(t, *view.ItemAt(510) == "255")
+		assertTrue(t, *view.ItemAt(598) == "299")
+
+		out = out.Filter(qframe.Filter{Column: "foo", Comparator: "in", Arg: []string{"1", "256"}})
+		assertEquals(t, qframe.New(
+			map[string]interface{}{"foo": []string{"1", "256"}},
+			newqf.Enums(map[string][]string{"foo": nil})), out)
+	})
+
+	t.Run("Concat merges values into wide representation", func(t *testing.T) {
+		input1, input2 := make([]string, 0), make([]string, 0)
+		for i := 0; i < 200; i++ {
+			input1 = append(input1, fmt.Sprintf("a%d", i))
+			input2 = append(input2, fmt.Sprintf("b%d", i), fmt.Sprintf("a%d", i))
+		}
+
+		enums := newqf.Enums(map[string][]string{"foo": nil})
+		out := qframe.Concat(
+			qframe.New(map[string]interface{}{"foo": input1}, enums),
+			qframe.New(map[string]interface{}{"foo": input2}, enums))
+		assertNotErr(t, out.Err)
+		assertEquals(t, qframe.New(map[string]interface{}{"foo": append(input1, input2...)}, enums), out)
+
+		distinct := out.Distinct()
+		assertNotErr(t, distinct.Err)
+		assertTrue(t, distinct.Len() == 400)
+	})
+
 	t.Run("Fails with too high cardinality column", func(t *testing.T) {
 		input := make([]string, 0)
-		for i := 0; i < 256; i++ {
+		for i := 0; i < 65536; i++ {
 			input = append(input, strconv.Itoa(i))
 		}
 
@@ -1222,8 +2213,10 @@ func TestQFrame_ReadJSON(t *testing.T) {
 		Name: 0, dtype: object
 	*/
 	testString := "FOO"
+	one, tr := 1, true
 	table := []struct {
 		input    string
+		configs  []newqf.ConfigFunc
 		expected map[string]interface{}
 	}{
 		{
@@ -1234,6 +2227,31 @@ func TestQFrame_ReadJSON(t *testing.T) {
 				// NOTE: The integers become floats if not explicitly typed
 				"STRING1": []string{"a", "b"}, "INT1": []float64{1, 2}, "FLOAT1": []float64{1.5, 2.5}, "BOOL1": []bool{true, false}},
 		},
+		{
+			input: `[
+				{"STRING1": "a", "INT1": 1, "FLOAT1": 1.5, "BOOL1": true},
+				{"STRING1": "b", "INT1": 2, "FLOAT1": 2.5, "BOOL1": false}]`,
+			configs: []newqf.ConfigFunc{newqf.InferInts(true)},
+			expected: map[string]interface{}{
+				"STRING1": []string{"a", "b"}, "INT1": []int{1, 2}, "FLOAT1": []float64{1.5, 2.5}, "BOOL1": []bool{true, false}},
+		},
+		{
+			input: `[
+				{"INT1": null, "FLOAT1": 1, "BOOL1": null},
+				{"INT1": 1, "FLOAT1": null, "BOOL1": true},
+				{"INT1": null, "FLOAT1": 2.5, "BOOL1": null}]`,
+			expected: map[string]interface{}{
+				"INT1": []float64{math.NaN(), 1, math.NaN()}, "FLOAT1": []float64{1, math.NaN(), 2.5}, "BOOL1": []*bool{nil, &tr, nil}},
+		},
+		{
+			input: `[
+				{"INT1": null, "FLOAT1": 1, "BOOL1": null},
+				{"INT1": 1, "FLOAT1": null, "BOOL1": true},
+				{"INT1": null, "FLOAT1": 2.5, "BOOL1": null}]`,
+			configs: []newqf.ConfigFunc{newqf.InferInts(true)},
+			expected: map[string]interface{}{
+				"INT1": []*int{nil, &one, nil}, "FLOAT1": []float64{1, math.NaN(), 2.5}, "BOOL1": []*bool{nil, &tr, nil}},
+		},
 		{
 			input: `[{"STRING1": "FOO"}, {"STRING1": null}]`,
 			expected: map[string]interface{}{
@@ -1243,7 +2261,7 @@ func TestQFrame_ReadJSON(t *testing.T) {
 
 	for i, tc := range table {
 		t.Run(fmt.Sprintf("FromJSON %d", i), func(t *testing.T) {
-			out := qframe.ReadJSON(strings.NewReader(tc.input))
+			out := qframe.ReadJSON(strings.NewReader(tc.input), tc.configs...)
 			assertNotErr(t, out.Err)
 			assertEquals(t, qframe.New(tc.expected), out)
 		})
@@ -1339,93 +2357,514 @@ func TestQFrame_ToJSONInt(t *testing.T) {
 	}
 }
 
-func TestQFrame_FilterEnum(t *testing.T) {
-	a, b, c, d, e := "a", "b", "c", "d", "e"
-	enums := newqf.Enums(map[string][]string{"COL1": {"a", "b", "c", "d", "e"}})
+func TestQFrame_ToJSONTime(t *testing.T) {
+	t1 := time.Date(2019, 1, 1, 10, 0, 0, 500, time.FixedZone("UTC+2", 2*60*60))
+	data := map[string]interface{}{"TIME": []*time.Time{&t1, nil}}
+	originalDf := qframe.New(data)
+	assertNotErr(t, originalDf.Err)
+
+	buf := new(bytes.Buffer)
+	err := originalDf.ToJSON(buf)
+	assertNotErr(t, err)
+	expected := `[{"TIME":"2019-01-01T10:00:00.0000005+02:00"},{"TIME":null}]`
+	if buf.String() != expected {
+		t.Errorf("Unexpected JSON string: %s", buf.String())
+	}
+}
+
+func TestQFrame_FilterTime(t *testing.T) {
+	t1 := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2, t3 := t1.Add(time.Hour), t1.Add(2*time.Hour)
 	in := qframe.New(map[string]interface{}{
-		"COL1": []*string{&b, &c, &a, nil, &e, &d, nil}}, enums)
+		"COL1": []*time.Time{&t1, &t2, nil, &t3},
+		"COL2": []*time.Time{&t2, &t2, &t2, nil},
+	})
 
 	table := []struct {
 		clause   qframe.FilterClause
-		expected map[string]interface{}
+		expected []*time.Time
 	}{
-		{
-			qframe.Filter{Column: "COL1", Comparator: ">", Arg: "b"},
-			map[string]interface{}{"COL1": []*string{&c, &e, &d}},
-		},
-		{
-			qframe.Filter{Column: "COL1", Comparator: "in", Arg: []string{"a", "b"}},
-			map[string]interface{}{"COL1": []*string{&b, &a}},
-		},
+		{qframe.Filter{Column: "COL1", Comparator: ">", Arg: t1}, []*time.Time{&t2, &t3}},
+		{qframe.Filter{Column: "COL1", Comparator: "<=", Arg: t2}, []*time.Time{&t1, &t2}},
+		{qframe.Filter{Column: "COL1", Comparator: "=", Arg: t2.In(time.FixedZone("UTC+2", 2*60*60))}, []*time.Time{&t2}},
+		{qframe.Filter{Column: "COL1", Comparator: "!=", Arg: t2}, []*time.Time{&t1, nil, &t3}},
+		{qframe.Filter{Column: "COL1", Comparator: ">=", Arg: "2019-01-01T01:00:00Z"}, []*time.Time{&t2, &t3}},
+		{qframe.Filter{Column: "COL1", Comparator: "in", Arg: []time.Time{t1, t3}}, []*time.Time{&t1, &t3}},
+		{qframe.Filter{Column: "COL1", Comparator: "<", Arg: types.ColumnName("COL2")}, []*time.Time{&t1}},
+		{qframe.Filter{Column: "COL1", Comparator: "!=", Arg: types.ColumnName("COL2")}, []*time.Time{&t1, nil, &t3}},
+		{qframe.Filter{Column: "COL1", Comparator: func(x time.Time) bool { return x.Hour() == 2 }}, []*time.Time{&t3}},
+		{qframe.Filter{Column: "COL1", Comparator: ">", Arg: t1, Inverse: true}, []*time.Time{&t1}},
 	}
 
 	for i, tc := range table {
-		t.Run(fmt.Sprintf("Filter enum %d", i), func(t *testing.T) {
-			expected := qframe.New(tc.expected, enums)
-			out := in.Filter(tc.clause)
-			assertEquals(t, expected, out)
+		t.Run(fmt.Sprintf("Filter %d", i), func(t *testing.T) {
+			out := in.Filter(tc.clause).Select("COL1")
+			assertNotErr(t, out.Err)
+			assertEquals(t, qframe.New(map[string]interface{}{"COL1": tc.expected}), out)
 		})
 	}
+
+	out := in.Filter(qframe.Filter{Column: "COL1", Comparator: "<", Arg: "2019-01-01"})
+	assertErr(t, out.Err, "cannot parse")
 }
 
-func TestQFrame_FilterString(t *testing.T) {
-	a, b, c, d, e := "a", "b", "c", "d", "e"
-	withNil := map[string]interface{}{"COL1": []*string{&b, &c, &a, nil, &e, &d, nil}}
+func TestQFrame_GroupByTime(t *testing.T) {
+	t1 := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2, t3 := t1.Add(time.Hour), t1.Add(2*time.Hour)
+	in := qframe.New(map[string]interface{}{
+		"COL1": []*time.Time{&t1, &t2, &t1, nil, nil},
+		"COL2": []*time.Time{&t3, &t2, &t1, &t2, nil},
+		"COL3": []int{1, 2, 3, 4, 5},
+	})
+
+	out := in.GroupBy(groupby.Columns("COL1"), groupby.Null(true)).Aggregate(
+		qframe.Aggregation{Fn: "max", Column: "COL2"},
+		qframe.Aggregation{Fn: "sum", Column: "COL3"})
+	assertNotErr(t, out.Err)
+
+	expected := qframe.New(map[string]interface{}{
+		"COL1": []*time.Time{nil, &t1, &t2},
+		"COL2": []*time.Time{&t2, &t3, &t2},
+		"COL3": []int{9, 4, 2},
+	})
+	assertEquals(t, expected, out.Sort(qframe.Order{Column: "COL1"}))
+
+	// Nulls form separate groups by default
+	out = in.GroupBy(groupby.Columns("COL1")).Aggregate(
+		qframe.Aggregation{Fn: "count_distinct", Column: "COL2"})
+	assertNotErr(t, out.Err)
+	expected = qframe.New(map[string]interface{}{
+		"COL1": []*time.Time{nil, nil, &t1, &t2},
+		"COL2": []int{1, 0, 2, 1},
+	})
+	assertEquals(t, expected, out.Sort(qframe.Order{Column: "COL1"}, qframe.Order{Column: "COL2", Reverse: true}))
+}
+
+func TestQFrame_ApplyTime(t *testing.T) {
+	t1 := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	in := qframe.New(map[string]interface{}{"COL1": []*time.Time{&t1, nil}})
+	out := in.Apply(
+		qframe.Instruction{Fn: func(x time.Time) int { return x.Year() }, DstCol: "YEAR", SrcCol1: "COL1"},
+		qframe.Instruction{Fn: func(x time.Time) time.Time { return x.Add(time.Hour) }, DstCol: "COL1", SrcCol1: "COL1"})
+	assertNotErr(t, out.Err)
+
+	t2 := t1.Add(time.Hour)
+	year := 2019
+	expected := qframe.New(map[string]interface{}{
+		"COL1": []*time.Time{&t2, nil},
+		"YEAR": []*int{&year, nil},
+	})
+	assertEquals(t, expected, out)
+}
+
+func decimals(strs ...string) []*decimal.Decimal {
+	result := make([]*decimal.Decimal, len(strs))
+	for i, s := range strs {
+		if s != "" {
+			d := decimal.MustParse(s)
+			result[i] = &d
+		}
+	}
+	return result
+}
+
+func TestQFrame_Decimal(t *testing.T) {
+	t.Run("Column scale is the largest scale of the values", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{"COL1": decimals("1.5", "", "-0.25", "10")})
+		assertNotErr(t, in.Err)
+
+		buf := new(bytes.Buffer)
+		assertNotErr(t, in.ToCSV(buf))
+		if buf.String() != "COL1\n1.50\n\n-0.25\n10.00\n" {
+			t.Errorf("Unexpected CSV: %s", buf.String())
+		}
+	})
+
+	t.Run("Parses strings given as decimal columns", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{"COL1": []string{"0.1", "0.2"}}, newqf.Decimals("COL1"))
+		assertNotErr(t, in.Err)
+		assertEquals(t, qframe.New(map[string]interface{}{"COL1": decimals("0.1", "0.2")}), in)
+	})
+
+	t.Run("Invalid decimal", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{"COL1": []string{"0.1", "abc"}}, newqf.Decimals("COL1"))
+		assertErr(t, in.Err, "invalid decimal")
+	})
+
+	t.Run("Unknown decimal column", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{"COL1": []string{"0.1"}}, newqf.Decimals("COL2"))
+		assertErr(t, in.Err, "unknown decimal columns")
+	})
+
+	t.Run("Concat with different scales", func(t *testing.T) {
+		a := qframe.New(map[string]interface{}{"COL1": decimals("1.5")})
+		b := qframe.New(map[string]interface{}{"COL1": decimals("0.125")})
+		out := qframe.Concat(a, b)
+		assertNotErr(t, out.Err)
+
+		v, err := out.DecimalView("COL1")
+		assertNotErr(t, err)
+		assertTrue(t, v.Scale() == 3)
+		assertTrue(t, v.ItemAt(0).String() == "1.500" && v.ItemAt(1).String() == "0.125")
+	})
+}
+
+func TestQFrame_DecimalView(t *testing.T) {
+	input := qframe.New(map[string]interface{}{"COL1": decimals("2.50", "", "-1")})
+	input = input.Sort(qframe.Order{Column: "COL1"})
+
+	v, err := input.DecimalView("COL1")
+	assertNotErr(t, err)
+
+	s := v.Slice()
+	assertTrue(t, v.Len() == 3)
+	assertTrue(t, v.IsNull(0) && s[0] == nil)
+	assertTrue(t, !v.IsNull(1) && v.ItemAt(1) == decimal.New(-100, 2) && *s[1] == decimal.New(-100, 2))
+	assertTrue(t, !v.IsNull(2) && v.ItemAt(2) == decimal.New(250, 2) && *s[2] == decimal.New(250, 2))
+
+	_, err = input.FloatView("COL1")
+	assertErr(t, err, "invalid column type")
+}
+
+func TestQFrame_FilterDecimal(t *testing.T) {
+	in := qframe.New(map[string]interface{}{
+		"COL1": decimals("1.10", "2.25", "", "3"),
+		"COL2": decimals("2.2", "2.2", "2.2", ""),
+	})
 
 	table := []struct {
-		input    map[string]interface{}
 		clause   qframe.FilterClause
-		expected map[string]interface{}
+		expected []*decimal.Decimal
 	}{
-		{
-			withNil,
-			qframe.Filter{Column: "COL1", Comparator: ">", Arg: "b"},
-			map[string]interface{}{"COL1": []*string{&c, &e, &d}},
-		},
-		{
-			withNil,
-			qframe.Filter{Column: "COL1", Comparator: "<", Arg: "b"},
-			map[string]interface{}{"COL1": []*string{&a}},
-		},
-		{
-			withNil,
-			qframe.Filter{Column: "COL1", Comparator: "!=", Arg: "a"},
-			map[string]interface{}{"COL1": []*string{&b, &c, nil, &e, &d, nil}},
-		},
-		{
-			withNil,
-			qframe.Filter{Column: "COL1", Comparator: "like", Arg: "b"},
-			map[string]interface{}{"COL1": []*string{&b}},
-		},
-		{
-			withNil,
-			qframe.Filter{Column: "COL1", Comparator: "in", Arg: []string{"a", "b"}},
-			map[string]interface{}{"COL1": []*string{&b, &a}},
-		},
+		{qframe.Filter{Column: "COL1", Comparator: ">", Arg: decimal.MustParse("1.1")}, decimals("2.25", "3")},
+		{qframe.Filter{Column: "COL1", Comparator: "<=", Arg: "2.25"}, decimals("1.10", "2.25")},
+		{qframe.Filter{Column: "COL1", Comparator: "=", Arg: 3}, decimals("3")},
+		{qframe.Filter{Column: "COL1", Comparator: "!=", Arg: "1.1"}, decimals("2.25", "", "3")},
+		// Comparisons against values with more decimals than the column
+		{qframe.Filter{Column: "COL1", Comparator: ">=", Arg: "2.249"}, decimals("2.25", "3")},
+		{qframe.Filter{Column: "COL1", Comparator: "<", Arg: "2.251"}, decimals("1.10", "2.25")},
+		{qframe.Filter{Column: "COL1", Comparator: "=", Arg: "2.251"}, decimals()},
+		{qframe.Filter{Column: "COL1", Comparator: "!=", Arg: "2.251"}, decimals("1.10", "2.25", "", "3")},
+		{qframe.Filter{Column: "COL1", Comparator: "<", Arg: "-1.001"}, decimals()},
+		{qframe.Filter{Column: "COL1", Comparator: "in", Arg: []string{"1.1", "3.00", "3.001"}}, decimals("1.10", "3")},
+		{qframe.Filter{Column: "COL1", Comparator: "<", Arg: types.ColumnName("COL2")}, decimals("1.10")},
+		{qframe.Filter{Column: "COL1", Comparator: "!=", Arg: types.ColumnName("COL2")}, decimals("1.10", "2.25", "", "3")},
+		{qframe.Filter{Column: "COL1", Comparator: "isnull"}, decimals("")},
+		{qframe.Filter{Column: "COL1", Comparator: func(x decimal.Decimal) bool { return x.Unscaled()%100 == 0 }}, decimals("3")},
+		{qframe.Filter{Column: "COL1", Comparator: ">", Arg: "1.1", Inverse: true}, decimals("1.10")},
 	}
 
 	for i, tc := range table {
-		t.Run(fmt.Sprintf("Filter string %d", i), func(t *testing.T) {
-			in := qframe.New(tc.input)
-			expected := qframe.New(tc.expected)
-			out := in.Filter(tc.clause)
-			assertEquals(t, expected, out)
+		t.Run(fmt.Sprintf("Filter %d", i), func(t *testing.T) {
+			out := in.Filter(tc.clause).Select("COL1")
+			assertNotErr(t, out.Err)
+			assertEquals(t, qframe.New(map[string]interface{}{"COL1": tc.expected}), out)
 		})
 	}
+
+	t.Run("Invalid comparison value", func(t *testing.T) {
+		out := in.Filter(qframe.Filter{Column: "COL1", Comparator: ">", Arg: "1,5"})
+		assertErr(t, out.Err, "invalid decimal")
+	})
 }
 
-func TestQFrame_LikeFilterString(t *testing.T) {
-	col1 := []string{"ABC", "AbC", "DEF", "ABCDEF", "abcdef", "FFF", "abc$def", "défåäöΦ"}
+func TestQFrame_AggregateDecimal(t *testing.T) {
+	a, b := "a", "b"
+	in := qframe.New(map[string]interface{}{
+		"COL1": []*string{&a, &a, &a, &b, &b},
+		"COL2": decimals("0.1", "0.2", "", "", ""),
+		"COL3": decimals("5.05", "-1", "3.5", "1", "2"),
+	})
 
-	// Add a couple of fields to be able to verify functionality for high cardinality enums
-	for i := 0; i < 200; i++ {
-		col1 = append(col1, fmt.Sprintf("foo%dbar", i))
+	out := in.GroupBy(groupby.Columns("COL1")).Aggregate(
+		qframe.Aggregation{Fn: "sum", Column: "COL2"},
+		qframe.Aggregation{Fn: "max", Column: "COL3", As: "MAX"},
+		qframe.Aggregation{Fn: "min", Column: "COL3", As: "MIN"},
+		qframe.Aggregation{Fn: "sum", Column: "COL3", As: "SUM"})
+	assertNotErr(t, out.Err)
+
+	expected := qframe.New(map[string]interface{}{
+		"COL1": []string{"a", "b"},
+		"COL2": decimals("0.3", ""),
+		"MAX":  decimals("5.05", "2"),
+		"MIN":  decimals("-1", "1"),
+		"SUM":  decimals("7.55", "3"),
+	})
+	assertEquals(t, expected, out.Sort(qframe.Order{Column: "COL1"}))
+
+	buf := new(bytes.Buffer)
+	assertNotErr(t, out.Sort(qframe.Order{Column: "COL1"}).Select("COL1", "SUM").ToCSV(buf))
+	if buf.String() != "COL1,SUM\na,7.55\nb,3.00\n" {
+		t.Errorf("Unexpected CSV: %s", buf.String())
 	}
 
-	data := map[string]interface{}{"COL1": col1}
-	for _, enums := range []map[string][]string{{}, {"COL1": nil}} {
-		table := []struct {
-			comparator string
+	t.Run("Sum out of range", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{"COL1": decimals("9223372036854775807", "1")})
+		out := in.GroupBy().Aggregate(qframe.Aggregation{Fn: "sum", Column: "COL1"})
+		assertErr(t, out.Err, "out of range")
+	})
+}
+
+func TestQFrame_ApplyDecimal(t *testing.T) {
+	in := qframe.New(map[string]interface{}{"COL1": decimals("1.25", "")})
+	out := in.Apply(
+		qframe.Instruction{Fn: function.FloatD, DstCol: "FLOAT", SrcCol1: "COL1"},
+		qframe.Instruction{Fn: func(x decimal.Decimal) decimal.Decimal {
+			d, _ := x.Add(decimal.MustParse("0.001"))
+			return d
+		}, DstCol: "COL1", SrcCol1: "COL1"})
+	assertNotErr(t, out.Err)
+
+	expected := qframe.New(map[string]interface{}{
+		"COL1":  decimals("1.251", ""),
+		"FLOAT": []float64{1.25, math.NaN()},
+	})
+	assertEquals(t, expected, out)
+}
+
+func TestQFrame_ReadCSVDecimal(t *testing.T) {
+	input := "abc,def\n12.5,1\n,2\n-0.05,3\n1e2,4\n"
+	out := qframe.ReadCSV(strings.NewReader(input), csv.Types(map[string]string{"abc": "decimal"}))
+	assertNotErr(t, out.Err)
+
+	expected := qframe.New(map[string]interface{}{
+		"abc": decimals("12.5", "", "-0.05", "100"),
+		"def": []int{1, 2, 3, 4}}, newqf.ColumnOrder("abc", "def"))
+	assertEquals(t, expected, out)
+
+	buf := new(bytes.Buffer)
+	assertNotErr(t, out.ToCSV(buf))
+	expectedCSV := "abc,def\n12.50,1\n,2\n-0.05,3\n100.00,4\n"
+	if buf.String() != expectedCSV {
+		t.Errorf("Unexpected CSV: %s", buf.String())
+	}
+
+	out = qframe.ReadCSV(strings.NewReader("abc\n1.0\n1.0.0\n"), csv.Types(map[string]string{"abc": "decimal"}))
+	assertErr(t, out.Err, "invalid decimal")
+}
+
+func TestQFrame_JSONDecimal(t *testing.T) {
+	input := `[{"COL1": 0.1, "COL2": 0.1}, {"COL1": null, "COL2": 0.2}, {"COL1": "12345678901234.56", "COL2": 0.3}]`
+	out := qframe.ReadJSON(strings.NewReader(input), newqf.Decimals("COL1"))
+	assertNotErr(t, out.Err)
+
+	expected := qframe.New(map[string]interface{}{
+		"COL1": decimals("0.1", "", "12345678901234.56"),
+		"COL2": []float64{0.1, 0.2, 0.3},
+	})
+	assertEquals(t, expected, out)
+
+	buf := new(bytes.Buffer)
+	assertNotErr(t, out.Select("COL1").ToJSON(buf))
+	expectedJSON := `[{"COL1":0.10},{"COL1":null},{"COL1":12345678901234.56}]`
+	if buf.String() != expectedJSON {
+		t.Errorf("Unexpected JSON string: %s", buf.String())
+	}
+}
+
+func TestQFrame_NarrowTypes(t *testing.T) {
+	nan32 := float32(math.NaN())
+	t.Run("Views", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{
+			"I8":  []int8{-128, 127},
+			"U32": []uint32{0, 4294967295},
+			"F32": []float32{1.5, nan32},
+		})
+		assertNotErr(t, in.Err)
+
+		i8v, err := in.Int8View("I8")
+		assertNotErr(t, err)
+		assertTrue(t, i8v.ItemAt(0) == -128 && i8v.ItemAt(1) == 127)
+
+		u32v, err := in.Uint32View("U32")
+		assertNotErr(t, err)
+		assertTrue(t, u32v.ItemAt(1) == 4294967295)
+
+		f32v, err := in.Float32View("F32")
+		assertNotErr(t, err)
+		assertTrue(t, f32v.ItemAt(0) == 1.5)
+		assertTrue(t, f32v.IsNull(1))
+	})
+
+	t.Run("Uses less memory", func(t *testing.T) {
+		narrow := qframe.New(map[string]interface{}{"COL1": make([]int16, 1000)})
+		wide := qframe.New(map[string]interface{}{"COL1": make([]int, 1000)})
+		// The index is the same size for both
+		assertTrue(t, wide.ByteSize()-narrow.ByteSize() >= 6*1000)
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{
+			"COL1": []int8{1, 2, 3},
+			"COL2": []int{3, 2, 1},
+			"COL3": []float32{0.1, 0.2, 0.3},
+		})
+
+		out := in.Filter(qframe.Filter{Column: "COL1", Comparator: ">", Arg: 1})
+		assertEquals(t, in.Filter(qframe.Filter{Column: "COL2", Comparator: "<", Arg: 3}), out)
+
+		out = in.Filter(qframe.Filter{Column: "COL1", Comparator: "<", Arg: types.ColumnName("COL2")})
+		assertEquals(t, in.Filter(qframe.Filter{Column: "COL2", Comparator: ">", Arg: 2}), out)
+
+		out = in.Filter(qframe.Filter{Column: "COL3", Comparator: "=", Arg: 0.1})
+		assertEquals(t, in.Filter(qframe.Filter{Column: "COL2", Comparator: "=", Arg: 3}), out)
+	})
+
+	t.Run("Aggregations produce 64 bit columns", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{
+			"COL1": []string{"a", "a", "b"},
+			"COL2": []uint8{200, 100, 1},
+			"COL3": []float32{0.5, 0.25, 1},
+		})
+
+		out := in.GroupBy(groupby.Columns("COL1")).Aggregate(
+			qframe.Aggregation{Fn: "sum", Column: "COL2"},
+			qframe.Aggregation{Fn: "sum", Column: "COL3"})
+		expected := qframe.New(map[string]interface{}{
+			"COL1": []string{"a", "b"},
+			"COL2": []int{300, 1},
+			"COL3": []float64{0.75, 1},
+		})
+		assertEquals(t, expected, out.Sort(qframe.Order{Column: "COL1"}))
+	})
+
+	t.Run("Eval widens when mixed with 64 bit columns", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{
+			"COL1": []int32{1, 2, 3},
+			"COL2": []int{10, 20, 30},
+			"COL3": []float32{0.5, 1.5, 2.5},
+		})
+
+		out := in.Eval("COL4", qframe.Expr("+", types.ColumnName("COL1"), types.ColumnName("COL2")))
+		out = out.Eval("COL5", qframe.Expr("+", types.ColumnName("COL3"), types.ColumnName("COL3")))
+		assertNotErr(t, out.Err)
+		expected := qframe.New(map[string]interface{}{
+			"COL4": []int{11, 22, 33},
+			"COL5": []float64{1, 3, 5},
+		})
+		assertEquals(t, expected, out.Select("COL4", "COL5"))
+	})
+
+	t.Run("Concat", func(t *testing.T) {
+		a := qframe.New(map[string]interface{}{"COL1": []uint16{1, 2}})
+		b := qframe.New(map[string]interface{}{"COL1": []uint16{3}})
+		assertEquals(t, qframe.New(map[string]interface{}{"COL1": []uint16{1, 2, 3}}), qframe.Concat(a, b))
+	})
+}
+
+func TestQFrame_ReadCSVNarrowTypes(t *testing.T) {
+	input := "abc,def,ghi\n1,-1.5,65535\n,,2\n"
+	out := qframe.ReadCSV(strings.NewReader(input), csv.Types(map[string]string{"abc": "int8", "def": "float32", "ghi": "uint16"}))
+	assertNotErr(t, out.Err)
+
+	assertTrue(t, out.ColumnTypeMap()["abc"] == types.Int8)
+	assertTrue(t, out.ColumnTypeMap()["def"] == types.Float32)
+	assertTrue(t, out.ColumnTypeMap()["ghi"] == types.Uint16)
+
+	buf := new(bytes.Buffer)
+	assertNotErr(t, out.ToCSV(buf))
+	if buf.String() != input {
+		t.Errorf("Unexpected CSV: %s", buf.String())
+	}
+
+	buf = new(bytes.Buffer)
+	assertNotErr(t, out.ToJSON(buf))
+	expectedJSON := `[{"abc":1,"def":-1.5,"ghi":65535},{"abc":null,"def":null,"ghi":2}]`
+	if buf.String() != expectedJSON {
+		t.Errorf("Unexpected JSON string: %s", buf.String())
+	}
+
+	out = qframe.ReadCSV(strings.NewReader("abc\n1\n128\n"), csv.Types(map[string]string{"abc": "int8"}))
+	assertErr(t, out.Err, "out of range")
+}
+
+func TestQFrame_FilterEnum(t *testing.T) {
+	a, b, c, d, e := "a", "b", "c", "d", "e"
+	enums := newqf.Enums(map[string][]string{"COL1": {"a", "b", "c", "d", "e"}})
+	in := qframe.New(map[string]interface{}{
+		"COL1": []*string{&b, &c, &a, nil, &e, &d, nil}}, enums)
+
+	table := []struct {
+		clause   qframe.FilterClause
+		expected map[string]interface{}
+	}{
+		{
+			qframe.Filter{Column: "COL1", Comparator: ">", Arg: "b"},
+			map[string]interface{}{"COL1": []*string{&c, &e, &d}},
+		},
+		{
+			qframe.Filter{Column: "COL1", Comparator: "in", Arg: []string{"a", "b"}},
+			map[string]interface{}{"COL1": []*string{&b, &a}},
+		},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("Filter enum %d", i), func(t *testing.T) {
+			expected := qframe.New(tc.expected, enums)
+			out := in.Filter(tc.clause)
+			assertEquals(t, expected, out)
+		})
+	}
+}
+
+func TestQFrame_FilterString(t *testing.T) {
+	a, b, c, d, e := "a", "b", "c", "d", "e"
+	withNil := map[string]interface{}{"COL1": []*string{&b, &c, &a, nil, &e, &d, nil}}
+
+	table := []struct {
+		input    map[string]interface{}
+		clause   qframe.FilterClause
+		expected map[string]interface{}
+	}{
+		{
+			withNil,
+			qframe.Filter{Column: "COL1", Comparator: ">", Arg: "b"},
+			map[string]interface{}{"COL1": []*string{&c, &e, &d}},
+		},
+		{
+			withNil,
+			qframe.Filter{Column: "COL1", Comparator: "<", Arg: "b"},
+			map[string]interface{}{"COL1": []*string{&a}},
+		},
+		{
+			withNil,
+			qframe.Filter{Column: "COL1", Comparator: "!=", Arg: "a"},
+			map[string]interface{}{"COL1": []*string{&b, &c, nil, &e, &d, nil}},
+		},
+		{
+			withNil,
+			qframe.Filter{Column: "COL1", Comparator: "like", Arg: "b"},
+			map[string]interface{}{"COL1": []*string{&b}},
+		},
+		{
+			withNil,
+			qframe.Filter{Column: "COL1", Comparator: "in", Arg: []string{"a", "b"}},
+			map[string]interface{}{"COL1": []*string{&b, &a}},
+		},
+	}
+
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("Filter string %d", i), func(t *testing.T) {
+			in := qframe.New(tc.input)
+			expected := qframe.New(tc.expected)
+			out := in.Filter(tc.clause)
+			assertEquals(t, expected, out)
+		})
+	}
+}
+
+func TestQFrame_LikeFilterString(t *testing.T) {
+	col1 := []string{"ABC", "AbC", "DEF", "ABCDEF", "abcdef", "FFF", "abc$def", "défåäöΦ"}
+
+	// Add a couple of fields to be able to verify functionality for high cardinality enums
+	for i := 0; i < 200; i++ {
+		col1 = append(col1, fmt.Sprintf("foo%dbar", i))
+	}
+
+	data := map[string]interface{}{"COL1": col1}
+	for _, enums := range []map[string][]string{{}, {"COL1": nil}} {
+		table := []struct {
+			comparator string
 			arg        string
 			expected   []string
 		}{
@@ -1626,153 +3065,1102 @@ func TestQFrame_ApplySingleArgEnum(t *testing.T) {
 	expectedNewGeneral := qframe.New(expectedData)
 	expectedNewBuiltIn := qframe.New(expectedData, newqf.Enums(map[string][]string{"COL1": nil}))
 
-	// General function
-	assertEquals(t, expectedNewGeneral, input.Apply(qframe.Instruction{Fn: toUpper, DstCol: "COL1", SrcCol1: "COL1"}))
+	// General function
+	assertEquals(t, expectedNewGeneral, input.Apply(qframe.Instruction{Fn: toUpper, DstCol: "COL1", SrcCol1: "COL1"}))
+
+	// Builtin function
+	assertEquals(t, expectedNewBuiltIn, input.Apply(qframe.Instruction{Fn: "ToUpper", DstCol: "COL1", SrcCol1: "COL1"}))
+}
+
+func TestQFrame_ApplyToCopyColumn(t *testing.T) {
+	a, b := "a", "b"
+	input := qframe.New(map[string]interface{}{
+		"COL1": []string{a, b}})
+
+	expectedNew := qframe.New(map[string]interface{}{
+		"COL1": []string{a, b},
+		"COL2": []string{a, b}})
+
+	assertEquals(t, expectedNew, input.Apply(qframe.Instruction{Fn: types.ColumnName("COL1"), DstCol: "COL2"}))
+}
+
+func TestQFrame_ApplyDoubleArg(t *testing.T) {
+	one, two, three := 1, 2, 3
+	table := []struct {
+		name     string
+		input    map[string]interface{}
+		expected interface{}
+		fn       interface{}
+		enums    map[string][]string
+	}{
+		{
+			name:     "int",
+			input:    map[string]interface{}{"COL1": []int{3, 2}, "COL2": []int{30, 20}},
+			expected: []int{33, 22},
+			fn:       func(a, b int) int { return a + b }},
+		{
+			name:     "int with nulls",
+			input:    map[string]interface{}{"COL1": []*int{&one, nil, &two}, "COL2": []*int{&two, &three, nil}},
+			expected: []*int{&three, nil, nil},
+			fn:       func(a, b int) int { return a + b }},
+		{
+			name:     "string",
+			input:    map[string]interface{}{"COL1": []string{"a", "b"}, "COL2": []string{"x", "y"}},
+			expected: []string{"ax", "by"},
+			fn:       func(a, b *string) *string { result := *a + *b; return &result }},
+		{
+			name:     "enum",
+			input:    map[string]interface{}{"COL1": []string{"a", "b"}, "COL2": []string{"x", "y"}},
+			expected: []string{"ax", "by"},
+			fn:       func(a, b *string) *string { result := *a + *b; return &result },
+			enums:    map[string][]string{"COL1": nil, "COL2": nil}},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			in := qframe.New(tc.input, newqf.Enums(tc.enums))
+			tc.input["COL3"] = tc.expected
+			expected := qframe.New(tc.input, newqf.Enums(tc.enums))
+			out := in.Apply(qframe.Instruction{Fn: tc.fn, DstCol: "COL3", SrcCol1: "COL1", SrcCol2: "COL2"})
+			assertEquals(t, expected, out)
+		})
+	}
+}
+
+func TestQFrame_FilteredApply(t *testing.T) {
+	plus1 := func(a int) int { return a + 1 }
+	table := []struct {
+		name         string
+		input        map[string]interface{}
+		expected     map[string]interface{}
+		instructions []qframe.Instruction
+		clauses      qframe.FilterClause
+	}{
+		{
+			name:         "null fills for rows that dont match filter when destination column is new",
+			input:        map[string]interface{}{"COL1": []int{3, 2, 1}},
+			instructions: []qframe.Instruction{{Fn: plus1, DstCol: "COL3", SrcCol1: "COL1"}, {Fn: plus1, DstCol: "COL3", SrcCol1: "COL3"}},
+			expected:     map[string]interface{}{"COL1": []int{3, 2, 1}, "COL3": []int{5, 4, 0}},
+			clauses:      qframe.Filter{Comparator: ">", Column: "COL1", Arg: 1}},
+		{
+			// One could question whether this is the desired behaviour or not. The alternative
+			// would be to preserve the existing values but that would cause a lot of inconsistencies
+			// when the result column type differs from the source column type for example. What would
+			// the preserved value be in that case? Preserving the existing behaviour could be achieved
+			// by using a temporary column that indexes which columns to modify and not. Perhaps this
+			// should be built in at some point.
+			name:         "null fills rows that dont match filter when destination column is existing",
+			input:        map[string]interface{}{"COL1": []int{3, 2, 1}},
+			instructions: []qframe.Instruction{{Fn: plus1, DstCol: "COL1", SrcCol1: "COL1"}},
+			expected:     map[string]interface{}{"COL1": []int{4, 3, 0}},
+			clauses:      qframe.Filter{Comparator: ">", Column: "COL1", Arg: 1}},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			in := qframe.New(tc.input)
+			expected := qframe.New(tc.expected)
+			out := in.FilteredApply(tc.clauses, tc.instructions...)
+			assertEquals(t, expected, out)
+		})
+	}
+}
+
+func TestQFrame_AggregateStrings(t *testing.T) {
+	table := []struct {
+		enums map[string][]string
+	}{
+		{map[string][]string{"COL2": nil}},
+		{map[string][]string{}},
+	}
+
+	for _, tc := range table {
+		t.Run(fmt.Sprintf("Enum %t", len(tc.enums) > 0), func(t *testing.T) {
+			input := qframe.New(map[string]interface{}{
+				"COL1": []string{"a", "b", "a", "b", "a"},
+				"COL2": []string{"x", "p", "y", "q", "z"},
+			}, newqf.Enums(tc.enums))
+			expected := qframe.New(map[string]interface{}{"COL1": []string{"a", "b"}, "COL2": []string{"x,y,z", "p,q"}})
+			out := input.GroupBy(groupby.Columns("COL1")).Aggregate(qframe.Aggregation{Fn: aggregation.StrJoin(","), Column: "COL2"})
+			assertEquals(t, expected, out.Sort(qframe.Order{Column: "COL1"}))
+		})
+	}
+}
+
+func TestQFrame_AggregateAs(t *testing.T) {
+	input := qframe.New(map[string]interface{}{
+		"COL1": []string{"a", "b", "a", "b", "a"},
+		"COL2": []int{1, 2, 3, 4, 5},
+	})
+
+	out := input.GroupBy(groupby.Columns("COL1")).Aggregate(
+		qframe.Aggregation{Fn: "sum", Column: "COL2", As: "SUM"},
+		qframe.Aggregation{Fn: "count", Column: "COL2", As: "COUNT"},
+		qframe.Aggregation{Fn: func(c []int) int { return c[len(c)-1] }, Column: "COL2"})
+
+	expected := qframe.New(map[string]interface{}{
+		"COL1":  []string{"a", "b"},
+		"SUM":   []int{9, 6},
+		"COUNT": []int{3, 2},
+		"COL2":  []int{5, 4}},
+		newqf.ColumnOrder("COL1", "SUM", "COUNT", "COL2"))
+	assertEquals(t, expected, out.Sort(qframe.Order{Column: "COL1"}))
+}
+
+func sum(c []int) int {
+	result := 0
+	for _, v := range c {
+		result += v
+	}
+	return result
+}
+
+func TestQFrame_AggregateGroupByNull(t *testing.T) {
+	a, b := "a", "b"
+	one, two, tr, fa := 1, 2, true, false
+	for _, groupByNull := range []bool{false, true} {
+		for _, column := range []string{"COL1", "COL2", "COL3", "COL5", "COL6"} {
+			t.Run(fmt.Sprintf("%s %v", column, groupByNull), func(t *testing.T) {
+				input := qframe.New(map[string]interface{}{
+					"COL1": []*string{&a, &b, nil, &a, &b, nil},
+					"COL2": []*string{&a, &b, nil, &a, &b, nil},
+					"COL3": []float64{1, 2, math.NaN(), 1, 2, math.NaN()},
+					"COL4": []int{1, 2, 3, 10, 20, 30},
+					"COL5": []*int{&one, &two, nil, &one, &two, nil},
+					"COL6": []*bool{&fa, &tr, nil, &fa, &tr, nil},
+				}, newqf.Enums(map[string][]string{"COL2": nil}))
+
+				col4 := []int{3, 30, 11, 22}
+				if groupByNull {
+					// Here we expect the nil/NaN/null columns to have been aggregated together
+					col4 = []int{33, 11, 22}
+				}
+				expected := qframe.New(map[string]interface{}{"COL4": col4})
+
+				out := input.GroupBy(groupby.Columns(column), groupby.Null(groupByNull)).Aggregate(qframe.Aggregation{Fn: sum, Column: "COL4"})
+				assertEquals(t, expected, out.Sort(colNamesToOrders(column, "COL4")...).Select("COL4"))
+			})
+		}
+	}
+}
+
+func TestQFrame_AggregateNullable(t *testing.T) {
+	one, three, tr, fa := 1, 3, true, false
+	input := qframe.New(map[string]interface{}{
+		"GROUP": []string{"a", "a", "b", "b", "c"},
+		"VAL":   []*int{&one, nil, nil, nil, &three},
+		"FLAG":  []*bool{&tr, nil, nil, nil, &fa}})
+
+	// Null values are not passed to the aggregation functions, groups with only nulls
+	// are null in the result unless the result type differs from the column type.
+	out := input.GroupBy(groupby.Columns("GROUP")).Aggregate(
+		qframe.Aggregation{Fn: "sum", Column: "VAL"},
+		qframe.Aggregation{Fn: "avg", Column: "VAL", As: "AVG"},
+		qframe.Aggregation{Fn: "majority", Column: "FLAG"},
+		qframe.Aggregation{Fn: func(v []int, f []bool) int { return len(v) }, Columns: []string{"VAL", "FLAG"}, As: "N"}).
+		Sort(qframe.Order{Column: "GROUP"})
+	assertNotErr(t, out.Err)
+
+	expected := qframe.New(map[string]interface{}{
+		"GROUP": []string{"a", "b", "c"},
+		"VAL":   []*int{&one, nil, &three},
+		"AVG":   []float64{1, math.NaN(), 3},
+		"FLAG":  []*bool{&tr, nil, &fa},
+		"N":     []int{1, 0, 1}},
+		newqf.ColumnOrder("GROUP", "VAL", "AVG", "FLAG", "N"))
+	assertEquals(t, expected, out)
+}
+
+func TestQFrame_Join(t *testing.T) {
+	a, b, c, d := "a", "b", "c", "d"
+	x, y, z, p, q, r := "x", "y", "z", "p", "q", "r"
+	i10, i20, i40, i100, i300, i400 := 10, 20, 40, 100, 300, 400
+	nan := math.NaN()
+	left := map[string]interface{}{
+		"KEY": []int{1, 2, 3, 2},
+		"VAL": []string{"a", "b", "c", "d"},
+		"X":   []int{10, 20, 30, 40}}
+	right := map[string]interface{}{
+		"KEY": []int{2, 4, 1, 2},
+		"Y":   []float64{1.5, 2.5, 3.5, 4.5},
+		"X":   []int{100, 200, 300, 400}}
+
+	table := []struct {
+		name     string
+		left     map[string]interface{}
+		right    map[string]interface{}
+		expected map[string]interface{}
+		order    []string
+		configs  []join.ConfigFunc
+	}{
+		{
+			name:  "inner join",
+			left:  left,
+			right: right,
+			expected: map[string]interface{}{
+				"KEY":     []int{1, 2, 2, 2, 2},
+				"VAL":     []string{"a", "b", "b", "d", "d"},
+				"X_left":  []int{10, 20, 20, 40, 40},
+				"Y":       []float64{3.5, 1.5, 4.5, 1.5, 4.5},
+				"X_right": []int{300, 100, 400, 100, 400}},
+			order:   []string{"KEY", "VAL", "X_left", "X_right", "Y"},
+			configs: []join.ConfigFunc{join.Columns("KEY")}},
+		{
+			name:  "left join",
+			left:  left,
+			right: right,
+			expected: map[string]interface{}{
+				"KEY":     []int{1, 2, 2, 3, 2, 2},
+				"VAL":     []string{"a", "b", "b", "c", "d", "d"},
+				"X_left":  []int{10, 20, 20, 30, 40, 40},
+				"Y":       []float64{3.5, 1.5, 4.5, nan, 1.5, 4.5},
+				"X_right": []*int{&i300, &i100, &i400, nil, &i100, &i400}},
+			order:   []string{"KEY", "VAL", "X_left", "X_right", "Y"},
+			configs: []join.ConfigFunc{join.Columns("KEY"), join.How("left")}},
+		{
+			name:  "right join",
+			left:  left,
+			right: right,
+			expected: map[string]interface{}{
+				"KEY":     []int{1, 2, 2, 2, 2, 4},
+				"VAL":     []*string{&a, &b, &b, &d, &d, nil},
+				"X_left":  []*int{&i10, &i20, &i20, &i40, &i40, nil},
+				"Y":       []float64{3.5, 1.5, 4.5, 1.5, 4.5, 2.5},
+				"X_right": []int{300, 100, 400, 100, 400, 200}},
+			order:   []string{"KEY", "VAL", "X_left", "X_right", "Y"},
+			configs: []join.ConfigFunc{join.Columns("KEY"), join.How("right")}},
+		{
+			name: "outer join on multiple columns with custom suffixes",
+			left: map[string]interface{}{
+				"K1": []string{"a", "a", "b"},
+				"K2": []int{1, 2, 1},
+				"V":  []string{"x", "y", "z"}},
+			right: map[string]interface{}{
+				"K1": []string{"a", "c", "b"},
+				"K2": []int{2, 1, 2},
+				"V":  []string{"p", "q", "r"}},
+			expected: map[string]interface{}{
+				"K1":  []string{"a", "a", "b", "c", "b"},
+				"K2":  []int{1, 2, 1, 1, 2},
+				"V_l": []*string{&x, &y, &z, nil, nil},
+				"V_r": []*string{nil, &p, nil, &q, &r}},
+			order:   []string{"K1", "K2", "V_l", "V_r"},
+			configs: []join.ConfigFunc{join.Columns("K1", "K2"), join.How("outer"), join.Suffixes("_l", "_r")}},
+		{
+			name: "join on common columns by default, nulls do not match",
+			left: map[string]interface{}{
+				"KEY": []*string{&a, nil, &c},
+				"V1":  []int{1, 2, 3}},
+			right: map[string]interface{}{
+				"KEY": []*string{nil, &a, &b},
+				"V2":  []bool{true, false, true}},
+			expected: map[string]interface{}{
+				"KEY": []string{"a"},
+				"V1":  []int{1},
+				"V2":  []bool{false}},
+			order: []string{"KEY", "V1", "V2"}},
+		{
+			name: "nulls match when configured",
+			left: map[string]interface{}{
+				"KEY": []*string{&a, nil, &c},
+				"V1":  []int{1, 2, 3}},
+			right: map[string]interface{}{
+				"KEY": []*string{nil, &a, &b},
+				"V2":  []bool{true, false, true}},
+			expected: map[string]interface{}{
+				"KEY": []*string{&a, nil},
+				"V1":  []int{1, 2},
+				"V2":  []bool{false, true}},
+			order:   []string{"KEY", "V1", "V2"},
+			configs: []join.ConfigFunc{join.Null(true)}},
+		{
+			name:     "empty frames",
+			left:     map[string]interface{}{"KEY": []int{}, "V1": []int{}},
+			right:    map[string]interface{}{"KEY": []int{}, "V2": []float64{}},
+			expected: map[string]interface{}{"KEY": []int{}, "V1": []int{}, "V2": []float64{}},
+			order:    []string{"KEY", "V1", "V2"},
+			configs:  []join.ConfigFunc{join.How("outer")}},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			l, r := qframe.New(tc.left), qframe.New(tc.right)
+			out := l.Join(r, tc.configs...)
+			assertNotErr(t, out.Err)
+			assertEquals(t, qframe.New(tc.expected, newqf.ColumnOrder(tc.order...)), out)
+		})
+	}
+}
+
+func TestQFrame_JoinEnum(t *testing.T) {
+	x, y, z := "x", "y", "z"
+	one, two, three := 1, 2, 3
+	l := qframe.New(map[string]interface{}{
+		"KEY": []string{"a", "b", "c"},
+		"V1":  []int{1, 2, 3}},
+		newqf.Enums(map[string][]string{"KEY": {"a", "b", "c"}}))
+	r := qframe.New(map[string]interface{}{
+		"KEY": []string{"d", "c", "a"},
+		"V2":  []string{"x", "y", "z"}},
+		newqf.Enums(map[string][]string{"KEY": nil}))
+
+	out := l.Join(r, join.How("outer")).Sort(qframe.Order{Column: "KEY"})
+	expected := qframe.New(map[string]interface{}{
+		"KEY": []string{"a", "b", "c", "d"},
+		"V1":  []*int{&one, &two, &three, nil},
+		"V2":  []*string{&z, nil, &y, &x}},
+		newqf.ColumnOrder("KEY", "V1", "V2"),
+		newqf.Enums(map[string][]string{"KEY": {"a", "b", "c", "d"}}))
+	assertEquals(t, expected, out)
+}
+
+func TestQFrame_Concat(t *testing.T) {
+	a, b := "a", "b"
+	one, two, three := 1, 2, 3
+	tr := true
+	nan := math.NaN()
+	table := []struct {
+		name     string
+		inputs   []map[string]interface{}
+		expected map[string]interface{}
+		order    []string
+		configs  []concat.ConfigFunc
+	}{
+		{
+			name: "same columns",
+			inputs: []map[string]interface{}{
+				{"COL1": []int{1, 2}, "COL2": []string{"a", "b"}},
+				{"COL1": []int{3}, "COL2": []string{"c"}}},
+			expected: map[string]interface{}{"COL1": []int{1, 2, 3}, "COL2": []string{"a", "b", "c"}},
+			order:    []string{"COL1", "COL2"}},
+		{
+			name: "missing columns are filled with nulls",
+			inputs: []map[string]interface{}{
+				{"COL1": []int{1, 2}, "COL2": []string{"a", "b"}},
+				{"COL1": []int{3}, "COL3": []float64{1.5}}},
+			expected: map[string]interface{}{
+				"COL1": []int{1, 2, 3},
+				"COL2": []*string{&a, &b, nil},
+				"COL3": []float64{nan, nan, 1.5}},
+			order: []string{"COL1", "COL2", "COL3"}},
+		{
+			name: "missing int and bool columns are filled with nulls",
+			inputs: []map[string]interface{}{
+				{"COL1": []int{1, 2}},
+				{"COL2": []int{3}, "COL3": []bool{true}}},
+			expected: map[string]interface{}{
+				"COL1": []*int{&one, &two, nil},
+				"COL2": []*int{nil, nil, &three},
+				"COL3": []*bool{nil, nil, &tr}},
+			order: []string{"COL1", "COL2", "COL3"}},
+		{
+			name: "int promoted to float",
+			inputs: []map[string]interface{}{
+				{"COL1": []int{1, 2}},
+				{"COL1": []float64{2.5}},
+				{"COL1": []int{3}}},
+			expected: map[string]interface{}{"COL1": []float64{1, 2, 2.5, 3}},
+			order:    []string{"COL1"},
+			configs:  []concat.ConfigFunc{concat.PromoteInt(true)}},
+		{
+			name: "empty frames",
+			inputs: []map[string]interface{}{
+				{"COL1": []int{}},
+				{"COL1": []int{1}},
+				{"COL1": []int{}}},
+			expected: map[string]interface{}{"COL1": []int{1}},
+			order:    []string{"COL1"}},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			frames := make([]qframe.QFrame, 0, len(tc.inputs))
+			for _, input := range tc.inputs {
+				frames = append(frames, qframe.New(input))
+			}
+
+			out := qframe.ConcatWith(tc.configs, frames...)
+			assertNotErr(t, out.Err)
+			assertEquals(t, qframe.New(tc.expected, newqf.ColumnOrder(tc.order...)), out)
+		})
+	}
+}
+
+func TestQFrame_ConcatEnum(t *testing.T) {
+	f1 := qframe.ReadCSV(strings.NewReader("COL1,COL2\na,1\nb,2"), csv.Types(map[string]string{"COL1": "enum"}))
+	f2 := qframe.ReadCSV(strings.NewReader("COL1,COL2\nc,3\na,4"), csv.Types(map[string]string{"COL1": "enum"}))
+	f3 := qframe.ReadCSV(strings.NewReader("COL2\n5"))
+
+	out := qframe.Concat(f1, f2, f3)
+	assertNotErr(t, out.Err)
+
+	a, b, c := "a", "b", "c"
+	expected := qframe.New(map[string]interface{}{
+		"COL1": []*string{&a, &b, &c, &a, nil},
+		"COL2": []int{1, 2, 3, 4, 5}},
+		newqf.Enums(map[string][]string{"COL1": nil}))
+	assertEquals(t, expected, out)
+
+	// Enum order is given by the order of appearance
+	filtered := out.Filter(qframe.Filter{Column: "COL1", Comparator: ">", Arg: "a"})
+	assertEquals(t, qframe.New(map[string]interface{}{"COL1": []string{"b", "c"}, "COL2": []int{2, 3}},
+		newqf.Enums(map[string][]string{"COL1": nil})), filtered)
+}
+
+func TestQFrame_ConcatErrors(t *testing.T) {
+	table := []struct {
+		name   string
+		frames []qframe.QFrame
+		err    string
+	}{
+		{
+			name:   "no frames",
+			frames: []qframe.QFrame{},
+			err:    "no frames"},
+		{
+			name: "type mismatch",
+			frames: []qframe.QFrame{
+				qframe.New(map[string]interface{}{"COL1": []int{1}}),
+				qframe.New(map[string]interface{}{"COL1": []string{"a"}})},
+			err: "type mismatch"},
+		{
+			name: "int and float without promotion",
+			frames: []qframe.QFrame{
+				qframe.New(map[string]interface{}{"COL1": []int{1}}),
+				qframe.New(map[string]interface{}{"COL1": []float64{1.5}})},
+			err: "type mismatch"},
+		{
+			name: "error in frame",
+			frames: []qframe.QFrame{
+				qframe.New(map[string]interface{}{"COL1": []int{1}}),
+				qframe.New(map[string]interface{}{"COL1": []int{1}}).Select("COL2")},
+			err: "unknown column"},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			out := qframe.Concat(tc.frames...)
+			assertErr(t, out.Err, tc.err)
+		})
+	}
+}
+
+func TestQFrame_ValueCounts(t *testing.T) {
+	a, b, c := "a", "b", "c"
+	in := qframe.New(map[string]interface{}{
+		"COL1": []*string{&b, &a, &c, nil, &b, &a, &b},
+	})
+
+	t.Run("Counts", func(t *testing.T) {
+		expected := qframe.New(map[string]interface{}{
+			"COL1":  []string{"b", "a", "c"},
+			"count": []int{3, 2, 1},
+		}, newqf.ColumnOrder("COL1", "count"))
+		assertEquals(t, expected, in.ValueCounts("COL1", false))
+	})
+
+	t.Run("Normalized", func(t *testing.T) {
+		expected := qframe.New(map[string]interface{}{
+			"COL1":  []string{"b", "a", "c"},
+			"count": []int{3, 2, 1},
+			"share": []float64{0.5, 2.0 / 6, 1.0 / 6},
+		}, newqf.ColumnOrder("COL1", "count", "share"))
+		assertEquals(t, expected, in.ValueCounts("COL1", true))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		assertErr(t, in.ValueCounts("COL2", false).Err, "unknown column")
+		countIn := qframe.New(map[string]interface{}{"count": []int{1}})
+		assertErr(t, countIn.ValueCounts("count", false).Err, "clashes")
+	})
+}
+
+func TestQFrame_Crosstab(t *testing.T) {
+	a, b, x, y, z := "a", "b", "x", "y", "z"
+	in := qframe.New(map[string]interface{}{
+		"COL1": []*string{&b, &a, &a, &b, nil, &a},
+		"COL2": []*string{&x, &y, &x, &z, &x, &y},
+	})
+
+	expected := qframe.New(map[string]interface{}{
+		"COL1": []string{"a", "b"},
+		"x":    []int{1, 1},
+		"y":    []int{2, 0},
+		"z":    []int{0, 1},
+	}, newqf.ColumnOrder("COL1", "x", "y", "z"))
+	assertEquals(t, expected, in.Crosstab("COL1", "COL2"))
+
+	t.Run("Column named count", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{"count": []int{1, 2, 1}, "COL2": []string{"x", "x", "y"}})
+		expected := qframe.New(map[string]interface{}{
+			"count": []int{1, 2},
+			"x":     []int{1, 1},
+			"y":     []int{1, 0},
+		}, newqf.ColumnOrder("count", "x", "y"))
+		assertEquals(t, expected, in.Crosstab("count", "COL2"))
+	})
+
+	t.Run("Same column", func(t *testing.T) {
+		assertErr(t, in.Crosstab("COL1", "COL1").Err, "different columns")
+	})
+}
+
+func TestQFrame_Cut(t *testing.T) {
+	one, two, five, ten := 1, 2, 5, 10
+	in := qframe.New(map[string]interface{}{
+		"INT":   []*int{&five, &one, nil, &ten, &two},
+		"FLOAT": []float64{0.5, 1, math.NaN(), 2.5, 11},
+	})
+
+	t.Run("Labels", func(t *testing.T) {
+		out := in.Cut("BUCKET", "INT", []float64{1, 2, 5, 10}, []string{"low", "mid", "high"})
+		assertNotErr(t, out.Err)
+
+		low, mid, high := "low", "mid", "high"
+		expected := qframe.New(map[string]interface{}{
+			"INT":    []*int{&five, &one, nil, &ten, &two},
+			"BUCKET": []*string{&mid, &low, nil, &high, &low},
+		}, newqf.Enums(map[string][]string{"BUCKET": {"low", "mid", "high"}}), newqf.ColumnOrder("INT", "BUCKET"))
+		assertEquals(t, expected, out.Select("INT", "BUCKET"))
+
+		// Buckets sort in bucket order rather than alphabetically
+		sorted := out.Sort(qframe.Order{Column: "BUCKET", NullLast: true}).Select("INT")
+		assertEquals(t, qframe.New(map[string]interface{}{"INT": []*int{&one, &two, &five, &ten, nil}}), sorted)
+	})
+
+	t.Run("Default labels", func(t *testing.T) {
+		out := in.Cut("BUCKET", "FLOAT", []float64{0.5, 1.5, 10}, nil)
+		assertNotErr(t, out.Err)
+
+		first, second := "[0.5, 1.5]", "(1.5, 10]"
+		expected := qframe.New(map[string]interface{}{
+			"BUCKET": []*string{&first, &first, nil, &second, nil},
+		}, newqf.Enums(map[string][]string{"BUCKET": {first, second}}))
+		assertEquals(t, expected, out.Select("BUCKET"))
+	})
+
+	t.Run("QCut", func(t *testing.T) {
+		values := qframe.New(map[string]interface{}{"COL1": []int{8, 1, 4, 2, 7, 3, 6, 5}})
+		out := values.QCut("QUARTILE", "COL1", 4, []string{"q1", "q2", "q3", "q4"})
+		assertNotErr(t, out.Err)
+
+		counts := out.ValueCounts("QUARTILE", false).Sort(qframe.Order{Column: "QUARTILE"})
+		expected := qframe.New(map[string]interface{}{
+			"QUARTILE": []string{"q1", "q2", "q3", "q4"},
+			"count":    []int{2, 2, 2, 2},
+		}, newqf.Enums(map[string][]string{"QUARTILE": {"q1", "q2", "q3", "q4"}}), newqf.ColumnOrder("QUARTILE", "count"))
+		assertEquals(t, expected, counts)
+
+		min := out.Filter(qframe.Filter{Column: "COL1", Comparator: "=", Arg: 1}).Select("QUARTILE")
+		q1 := "q1"
+		assertEquals(t, qframe.New(map[string]interface{}{"QUARTILE": []*string{&q1}},
+			newqf.Enums(map[string][]string{"QUARTILE": {"q1", "q2", "q3", "q4"}})), min)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		assertErr(t, in.Cut("B", "INT", []float64{1}, nil).Err, "at least two edges")
+		assertErr(t, in.Cut("B", "INT", []float64{2, 1}, nil).Err, "strictly increasing")
+		assertErr(t, in.Cut("B", "INT", []float64{1, 2, 3}, []string{"a"}).Err, "labels")
+		assertErr(t, in.Cut("B", "INT", []float64{1, 2, 3}, []string{"a", "a"}).Err, "duplicate label")
+		assertErr(t, in.Cut("B", "FOO", []float64{1, 2}, nil).Err, "unknown column")
+		assertErr(t, qframe.New(map[string]interface{}{"S": []string{"a"}}).Cut("B", "S", []float64{1, 2}, nil).Err, "cannot cut")
+		assertErr(t, qframe.New(map[string]interface{}{"I": []int{1, 1, 1, 2}}).QCut("B", "I", 4, nil).Err, "duplicate bucket edge")
+	})
+}
+
+func TestQFrame_FillNull(t *testing.T) {
+	a, b := "a", "b"
+	one, two := 1, 2
+	in := qframe.New(map[string]interface{}{
+		"INT":   []*int{&one, nil, &two},
+		"FLOAT": []float64{math.NaN(), 1.5, math.NaN()},
+		"STR":   []*string{nil, &a, nil},
+		"ENUM":  []*string{&a, nil, &b},
+		"DEC":   decimals("1.5", "", ""),
+		"I8":    []int8{1, 2, 3},
+	}, newqf.Enums(map[string][]string{"ENUM": {"a", "b"}}),
+		newqf.ColumnOrder("INT", "FLOAT", "STR", "ENUM", "DEC", "I8"))
+
+	out := in.FillNull("INT", 0).
+		FillNull("FLOAT", -1.0).
+		FillNull("STR", "z").
+		FillNull("ENUM", "b").
+		FillNull("DEC", decimal.MustParse("0.25")).
+		FillNull("I8", 0)
+	assertNotErr(t, out.Err)
+
+	expected := qframe.New(map[string]interface{}{
+		"INT":   []int{1, 0, 2},
+		"FLOAT": []float64{-1, 1.5, -1},
+		"STR":   []string{"z", "a", "z"},
+		"ENUM":  []string{"a", "b", "b"},
+		"DEC":   decimals("1.5", "0.25", "0.25"),
+		"I8":    []int8{1, 2, 3},
+	}, newqf.Enums(map[string][]string{"ENUM": {"a", "b"}}),
+		newqf.ColumnOrder("INT", "FLOAT", "STR", "ENUM", "DEC", "I8"))
+	assertEquals(t, expected, out)
+
+	t.Run("Errors", func(t *testing.T) {
+		assertErr(t, in.FillNull("INT", 1.5).Err, "invalid fill value type")
+		assertErr(t, in.FillNull("ENUM", "c").Err, "unknown enum value")
+		assertErr(t, in.FillNull("I8", 1000).Err, "out of range")
+		assertErr(t, in.FillNull("FOO", 1).Err, "unknown column")
+	})
+}
+
+func TestQFrame_FFillBFill(t *testing.T) {
+	one, two, three := 1, 2, 3
+	a, b := "a", "b"
+	nan := math.NaN()
+	in := qframe.New(map[string]interface{}{
+		"GROUP": []string{"x", "y", "x", "y", "x", "y"},
+		"INT":   []*int{nil, &one, nil, nil, &three, &two},
+		"FLOAT": []float64{1, nan, nan, 2, nan, nan},
+		"STR":   []*string{&a, nil, nil, &b, nil, nil},
+	}, newqf.ColumnOrder("GROUP", "INT", "FLOAT", "STR"))
+
+	t.Run("FFill", func(t *testing.T) {
+		expected := qframe.New(map[string]interface{}{
+			"GROUP": []string{"x", "y", "x", "y", "x", "y"},
+			"INT":   []*int{nil, &one, &one, &one, &three, &two},
+			"FLOAT": []float64{1, 1, 1, 2, 2, 2},
+			"STR":   []*string{&a, &a, &a, &b, &b, &b},
+		}, newqf.ColumnOrder("GROUP", "INT", "FLOAT", "STR"))
+		assertEquals(t, expected, in.FFill())
+	})
+
+	t.Run("BFill", func(t *testing.T) {
+		expected := qframe.New(map[string]interface{}{
+			"GROUP": []string{"x", "y", "x", "y", "x", "y"},
+			"INT":   []*int{&one, &one, &three, &three, &three, &two},
+			"FLOAT": []float64{1, 2, 2, 2, nan, nan},
+			"STR":   []*string{&a, nil, nil, &b, nil, nil},
+		}, newqf.ColumnOrder("GROUP", "INT", "FLOAT", "STR"))
+		assertEquals(t, expected, in.BFill("INT", "FLOAT"))
+	})
+
+	t.Run("FFill within groups", func(t *testing.T) {
+		expected := qframe.New(map[string]interface{}{
+			"GROUP": []string{"x", "y", "x", "y", "x", "y"},
+			"INT":   []*int{nil, &one, nil, &one, &three, &two},
+			"FLOAT": []float64{1, nan, 1, 2, 1, 2},
+			"STR":   []*string{&a, nil, &a, &b, &a, &b},
+		}, newqf.ColumnOrder("GROUP", "INT", "FLOAT", "STR"))
+		assertEquals(t, expected, in.GroupBy(groupby.Columns("GROUP")).FFill())
+	})
+
+	t.Run("BFill within groups", func(t *testing.T) {
+		expected := qframe.New(map[string]interface{}{
+			"GROUP": []string{"x", "y", "x", "y", "x", "y"},
+			"INT":   []*int{&three, &one, &three, &two, &three, &two},
+		}, newqf.ColumnOrder("GROUP", "INT"))
+		assertEquals(t, expected, in.GroupBy(groupby.Columns("GROUP")).BFill("INT").Select("GROUP", "INT"))
+	})
 
-	// Builtin function
-	assertEquals(t, expectedNewBuiltIn, input.Apply(qframe.Instruction{Fn: "ToUpper", DstCol: "COL1", SrcCol1: "COL1"}))
+	t.Run("Unknown column", func(t *testing.T) {
+		assertErr(t, in.FFill("FOO").Err, "unknown column")
+	})
 }
 
-func TestQFrame_ApplyToCopyColumn(t *testing.T) {
-	a, b := "a", "b"
-	input := qframe.New(map[string]interface{}{
-		"COL1": []string{a, b}})
+func TestQFrame_DropNull(t *testing.T) {
+	one, two := 1, 2
+	a := "a"
+	in := qframe.New(map[string]interface{}{
+		"ROW":   []int{0, 1, 2, 3},
+		"INT":   []*int{&one, nil, nil, &two},
+		"FLOAT": []float64{1, 2, math.NaN(), math.NaN()},
+		"STR":   []*string{&a, &a, nil, &a},
+	}, newqf.ColumnOrder("ROW", "INT", "FLOAT", "STR"))
 
-	expectedNew := qframe.New(map[string]interface{}{
-		"COL1": []string{a, b},
-		"COL2": []string{a, b}})
+	table := []struct {
+		how      string
+		cols     []string
+		expected []int
+	}{
+		{how: "any", expected: []int{0}},
+		{how: "all", cols: []string{"INT", "FLOAT", "STR"}, expected: []int{0, 1, 3}},
+		{how: "any", cols: []string{"INT", "STR"}, expected: []int{0, 3}},
+		{how: "all", cols: []string{"INT", "FLOAT"}, expected: []int{0, 1, 3}},
+		{how: "any", cols: []string{"FLOAT"}, expected: []int{0, 1}},
+	}
 
-	assertEquals(t, expectedNew, input.Apply(qframe.Instruction{Fn: types.ColumnName("COL1"), DstCol: "COL2"}))
+	for i, tc := range table {
+		t.Run(fmt.Sprintf("DropNull %d", i), func(t *testing.T) {
+			out := in.DropNull(tc.how, tc.cols...)
+			assertEquals(t, qframe.New(map[string]interface{}{"ROW": tc.expected}), out.Select("ROW"))
+		})
+	}
+
+	assertErr(t, in.DropNull("some").Err, `"any" or "all"`)
 }
 
-func TestQFrame_ApplyDoubleArg(t *testing.T) {
+func TestQFrame_Interpolate(t *testing.T) {
+	nan := math.NaN()
+	in := qframe.New(map[string]interface{}{
+		"GROUP": []string{"a", "a", "a", "a", "a", "b", "b", "b"},
+		"X":     []int{0, 1, 4, 5, 6, 7, 8, 9},
+		"VAL":   []float64{nan, 1, nan, 4, nan, 1, nan, 3},
+	}, newqf.ColumnOrder("GROUP", "X", "VAL"))
+
 	table := []struct {
 		name     string
-		input    map[string]interface{}
-		expected interface{}
-		fn       interface{}
-		enums    map[string][]string
+		configs  []interpolate.ConfigFunc
+		expected []float64
 	}{
-		{
-			name:     "int",
-			input:    map[string]interface{}{"COL1": []int{3, 2}, "COL2": []int{30, 20}},
-			expected: []int{33, 22},
-			fn:       func(a, b int) int { return a + b }},
-		{
-			name:     "string",
-			input:    map[string]interface{}{"COL1": []string{"a", "b"}, "COL2": []string{"x", "y"}},
-			expected: []string{"ax", "by"},
-			fn:       func(a, b *string) *string { result := *a + *b; return &result }},
-		{
-			name:     "enum",
-			input:    map[string]interface{}{"COL1": []string{"a", "b"}, "COL2": []string{"x", "y"}},
-			expected: []string{"ax", "by"},
-			fn:       func(a, b *string) *string { result := *a + *b; return &result },
-			enums:    map[string][]string{"COL1": nil, "COL2": nil}},
+		{name: "linear", expected: []float64{nan, 1, 2.5, 4, 2.5, 1, 2, 3}},
+		{name: "linear x", configs: []interpolate.ConfigFunc{interpolate.XColumn("X")}, expected: []float64{nan, 1, 3.25, 4, 2.5, 1, 2, 3}},
+		{name: "nearest x", configs: []interpolate.ConfigFunc{interpolate.Method("nearest"), interpolate.XColumn("X")}, expected: []float64{nan, 1, 4, 4, 4, 1, 1, 3}},
+		{name: "previous", configs: []interpolate.ConfigFunc{interpolate.Method("previous")}, expected: []float64{nan, 1, 1, 4, 4, 1, 1, 3}},
 	}
 
 	for _, tc := range table {
 		t.Run(tc.name, func(t *testing.T) {
-			in := qframe.New(tc.input, newqf.Enums(tc.enums))
-			tc.input["COL3"] = tc.expected
-			expected := qframe.New(tc.input, newqf.Enums(tc.enums))
-			out := in.Apply(qframe.Instruction{Fn: tc.fn, DstCol: "COL3", SrcCol1: "COL1", SrcCol2: "COL2"})
-			assertEquals(t, expected, out)
+			out := in.Interpolate("RESULT", "VAL", tc.configs...)
+			assertNotErr(t, out.Err)
+			assertEquals(t, qframe.New(map[string]interface{}{"RESULT": tc.expected}), out.Select("RESULT"))
 		})
 	}
+
+	t.Run("Within groups", func(t *testing.T) {
+		out := in.GroupBy(groupby.Columns("GROUP")).Interpolate("RESULT", "VAL")
+		assertNotErr(t, out.Err)
+		expected := []float64{nan, 1, 2.5, 4, nan, 1, 2, 3}
+		assertEquals(t, qframe.New(map[string]interface{}{"RESULT": expected}), out.Select("RESULT"))
+	})
+
+	t.Run("Max gap", func(t *testing.T) {
+		gaps := qframe.New(map[string]interface{}{"VAL": []float64{0, nan, nan, 3, nan, 5}})
+		out := gaps.Interpolate("VAL", "VAL", interpolate.MaxGap(1))
+		assertEquals(t, qframe.New(map[string]interface{}{"VAL": []float64{0, nan, nan, 3, 4, 5}}), out)
+	})
+
+	t.Run("Float32", func(t *testing.T) {
+		f32 := qframe.New(map[string]interface{}{"VAL": []float32{1, float32(nan), 2}})
+		out := f32.Interpolate("VAL", "VAL")
+		assertEquals(t, qframe.New(map[string]interface{}{"VAL": []float64{1, 1.5, 2}}), out)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		assertErr(t, in.Interpolate("R", "X").Err, "only float columns")
+		assertErr(t, in.Interpolate("R", "VAL", interpolate.XColumn("GROUP")).Err, "int or float column")
+		assertErr(t, in.Interpolate("R", "VAL", interpolate.Method("cubic")).Err, "linear/nearest/previous")
+		assertErr(t, in.Interpolate("R", "VAL", interpolate.MaxGap(-1)).Err, "negative")
+		assertErr(t, in.Interpolate("R", "FOO").Err, "unknown column")
+	})
 }
 
-func TestQFrame_FilteredApply(t *testing.T) {
-	plus1 := func(a int) int { return a + 1 }
+func TestQFrame_Cast(t *testing.T) {
+	one, two, zero := 1, 2, 0
+	t1, f := true, false
+	a, b, s1, s2, s15 := "a", "b", "1", "2", "1.5"
+
 	table := []struct {
-		name         string
-		input        map[string]interface{}
-		expected     map[string]interface{}
-		instructions []qframe.Instruction
-		clauses      qframe.FilterClause
+		name     string
+		input    interface{}
+		dataType types.DataType
+		configs  []cast.ConfigFunc
+		expected interface{}
+		err      string
 	}{
-		{
-			name:         "null fills for rows that dont match filter when destination column is new",
-			input:        map[string]interface{}{"COL1": []int{3, 2, 1}},
-			instructions: []qframe.Instruction{{Fn: plus1, DstCol: "COL3", SrcCol1: "COL1"}, {Fn: plus1, DstCol: "COL3", SrcCol1: "COL3"}},
-			expected:     map[string]interface{}{"COL1": []int{3, 2, 1}, "COL3": []int{5, 4, 0}},
-			clauses:      qframe.Filter{Comparator: ">", Column: "COL1", Arg: 1}},
-		{
-			// One could question whether this is the desired behaviour or not. The alternative
-			// would be to preserve the existing values but that would cause a lot of inconsistencies
-			// when the result column type differs from the source column type for example. What would
-			// the preserved value be in that case? Preserving the existing behaviour could be achieved
-			// by using a temporary column that indexes which columns to modify and not. Perhaps this
-			// should be built in at some point.
-			name:         "null fills rows that dont match filter when destination column is existing",
-			input:        map[string]interface{}{"COL1": []int{3, 2, 1}},
-			instructions: []qframe.Instruction{{Fn: plus1, DstCol: "COL1", SrcCol1: "COL1"}},
-			expected:     map[string]interface{}{"COL1": []int{4, 3, 0}},
-			clauses:      qframe.Filter{Comparator: ">", Column: "COL1", Arg: 1}},
+		{name: "int to float", input: []*int{&one, nil}, dataType: types.Float, expected: []float64{1, math.NaN()}},
+		{name: "int to bool", input: []*int{&one, &zero, nil}, dataType: types.Bool, expected: []*bool{&t1, &f, nil}},
+		{name: "int to string", input: []*int{&one, nil}, dataType: types.String, expected: []*string{&s1, nil}},
+		{name: "float to int", input: []float64{1, math.NaN(), 2}, dataType: types.Int, expected: []*int{&one, nil, &two}},
+		{name: "float with decimals to int", input: []float64{1.5}, dataType: types.Int, err: `cannot cast "1.5" at row 0 to int`},
+		{name: "float with decimals to int null", input: []float64{1.5, 2}, dataType: types.Int,
+			configs: []cast.ConfigFunc{cast.OnFailure("null")}, expected: []*int{nil, &two}},
+		{name: "float to string", input: []float64{1.5, math.NaN()}, dataType: types.String, expected: []*string{&s15, nil}},
+		{name: "bool to int", input: []*bool{&t1, &f, nil}, dataType: types.Int, expected: []*int{&one, &zero, nil}},
+		{name: "bool to float", input: []bool{true, false}, dataType: types.Float, expected: []float64{1, 0}},
+		{name: "string to int", input: []*string{&s1, nil, &s2}, dataType: types.Int, expected: []*int{&one, nil, &two}},
+		{name: "string to int error", input: []*string{&s1, &a}, dataType: types.Int, err: `cannot cast "a" at row 1 to int`},
+		{name: "string to int default", input: []*string{&s1, &a}, dataType: types.Int,
+			configs: []cast.ConfigFunc{cast.Default(-1)}, expected: []int{1, -1}},
+		{name: "string to float", input: []*string{&s15, &a}, dataType: types.Float,
+			configs: []cast.ConfigFunc{cast.OnFailure("null")}, expected: []float64{1.5, math.NaN()}},
+		{name: "string to bool", input: []string{"true", "false", "x"}, dataType: types.Bool,
+			configs: []cast.ConfigFunc{cast.Default(true)}, expected: []bool{true, false, true}},
+		{name: "invalid default type", input: []string{"x"}, dataType: types.Int,
+			configs: []cast.ConfigFunc{cast.Default("1")}, err: "invalid default value type"},
+		{name: "int8 to string", input: []int8{-1}, dataType: types.String, expected: []string{"-1"}},
+		{name: "time to float", input: []time.Time{{}}, dataType: types.Float, err: "cannot cast time to float"},
+		{name: "to time", input: []int{1}, dataType: types.Time, err: "cannot cast to time"},
 	}
 
 	for _, tc := range table {
 		t.Run(tc.name, func(t *testing.T) {
-			in := qframe.New(tc.input)
-			expected := qframe.New(tc.expected)
-			out := in.FilteredApply(tc.clauses, tc.instructions...)
-			assertEquals(t, expected, out)
+			in := qframe.New(map[string]interface{}{"COL1": tc.input})
+			out := in.Cast("COL1", tc.dataType, tc.configs...)
+			if tc.err != "" {
+				assertErr(t, out.Err, tc.err)
+				return
+			}
+
+			assertNotErr(t, out.Err)
+			assertEquals(t, qframe.New(map[string]interface{}{"COL1": tc.expected}), out)
 		})
 	}
+
+	t.Run("Enum", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{"COL1": []*string{&b, &a, nil, &b}})
+		out := in.Cast("COL1", types.Enum, cast.EnumValues("b", "a"))
+		expected := qframe.New(map[string]interface{}{"COL1": []*string{&b, &a, nil, &b}},
+			newqf.Enums(map[string][]string{"COL1": {"b", "a"}}))
+		assertEquals(t, expected, out)
+		assertEquals(t, in, out.Cast("COL1", types.String))
+
+		out = in.Cast("COL1", types.Enum, cast.EnumValues("a"))
+		assertErr(t, out.Err, `cannot cast "b" at row 0 to enum`)
+
+		out = in.Cast("COL1", types.Enum, cast.EnumValues("a"), cast.OnFailure("null"))
+		expected = qframe.New(map[string]interface{}{"COL1": []*string{nil, &a, nil, nil}},
+			newqf.Enums(map[string][]string{"COL1": {"a"}}))
+		assertEquals(t, expected, out)
+	})
+
+	t.Run("Enum to int", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{"COL1": []string{"1", "2"}}, newqf.Enums(map[string][]string{"COL1": nil}))
+		assertEquals(t, qframe.New(map[string]interface{}{"COL1": []int{1, 2}}), in.Cast("COL1", types.Int))
+	})
+
+	t.Run("Only rows in the QFrame are cast", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{"COL1": []string{"1", "a", "2"}})
+		out := in.Filter(qframe.Filter{Column: "COL1", Comparator: "!=", Arg: "a"}).Cast("COL1", types.Int)
+		assertEquals(t, qframe.New(map[string]interface{}{"COL1": []int{1, 2}}), out)
+	})
+
+	t.Run("Invalid failure policy", func(t *testing.T) {
+		in := qframe.New(map[string]interface{}{"COL1": []int{1}})
+		assertErr(t, in.Cast("COL1", types.Float, cast.OnFailure("ignore")).Err, "error/null/default")
+	})
 }
 
-func TestQFrame_AggregateStrings(t *testing.T) {
+func TestQFrame_Pivot(t *testing.T) {
+	one, two, three, four, five, six := 1, 2, 3, 4, 5, 6
+	tr, fa := true, false
+	d1, d2 := "d1", "d2"
+	input := map[string]interface{}{
+		"DATE": []*string{&d1, &d1, &d2, &d2, &d1, nil},
+		"CITY": []string{"x", "y", "x", "z", "x", "x"},
+		"TEMP": []int{1, 2, 3, 4, 5, 6}}
+	maxFn := func(xx []int) int {
+		result := xx[0]
+		for _, x := range xx {
+			if x > result {
+				result = x
+			}
+		}
+		return result
+	}
+
 	table := []struct {
-		enums map[string][]string
+		name     string
+		input    map[string]interface{}
+		columns  string
+		values   string
+		agg      types.SliceFuncOrBuiltInId
+		expected map[string]interface{}
+		order    []string
 	}{
-		{map[string][]string{"COL2": nil}},
-		{map[string][]string{}},
+		{
+			name:    "built in aggregation",
+			input:   input,
+			columns: "CITY",
+			values:  "TEMP",
+			agg:     "sum",
+			expected: map[string]interface{}{
+				"DATE": []string{"d1", "d2"},
+				"x":    []*int{&six, &three},
+				"y":    []*int{&two, nil},
+				"z":    []*int{nil, &four}},
+			order: []string{"DATE", "x", "y", "z"}},
+		{
+			name:    "custom aggregation",
+			input:   input,
+			columns: "CITY",
+			values:  "TEMP",
+			agg:     maxFn,
+			expected: map[string]interface{}{
+				"DATE": []string{"d1", "d2"},
+				"x":    []*int{&five, &three},
+				"y":    []*int{&two, nil},
+				"z":    []*int{nil, &four}},
+			order: []string{"DATE", "x", "y", "z"}},
+		{
+			name:    "count",
+			input:   input,
+			columns: "CITY",
+			values:  "TEMP",
+			agg:     "count",
+			expected: map[string]interface{}{
+				"DATE": []string{"d1", "d2"},
+				"x":    []*int{&two, &one},
+				"y":    []*int{&one, nil},
+				"z":    []*int{nil, &one}},
+			order: []string{"DATE", "x", "y", "z"}},
+		{
+			name: "no missing combinations keeps type",
+			input: map[string]interface{}{
+				"ID":  []int{2, 1, 2, 1},
+				"KEY": []string{"b", "a", "a", "b"},
+				"VAL": []int{1, 2, 3, 4}},
+			columns: "KEY",
+			values:  "VAL",
+			agg:     "sum",
+			expected: map[string]interface{}{
+				"ID": []int{1, 2},
+				"a":  []int{2, 3},
+				"b":  []int{4, 1}},
+			order: []string{"ID", "a", "b"}},
+		{
+			name: "bool values",
+			input: map[string]interface{}{
+				"ID":  []int{1, 2, 1},
+				"KEY": []string{"a", "a", "b"},
+				"VAL": []bool{true, false, false}},
+			columns: "KEY",
+			values:  "VAL",
+			agg:     "majority",
+			expected: map[string]interface{}{
+				"ID": []int{1, 2},
+				"a":  []*bool{&tr, &fa},
+				"b":  []*bool{&fa, nil}},
+			order: []string{"ID", "a", "b"}},
 	}
 
 	for _, tc := range table {
-		t.Run(fmt.Sprintf("Enum %t", len(tc.enums) > 0), func(t *testing.T) {
-			input := qframe.New(map[string]interface{}{
-				"COL1": []string{"a", "b", "a", "b", "a"},
-				"COL2": []string{"x", "p", "y", "q", "z"},
-			}, newqf.Enums(tc.enums))
-			expected := qframe.New(map[string]interface{}{"COL1": []string{"a", "b"}, "COL2": []string{"x,y,z", "p,q"}})
-			out := input.GroupBy(groupby.Columns("COL1")).Aggregate(qframe.Aggregation{Fn: aggregation.StrJoin(","), Column: "COL2"})
-			assertEquals(t, expected, out.Sort(qframe.Order{Column: "COL1"}))
+		t.Run(tc.name, func(t *testing.T) {
+			out := qframe.New(tc.input).Pivot(tc.order[0], tc.columns, tc.values, tc.agg)
+			assertNotErr(t, out.Err)
+			assertEquals(t, qframe.New(tc.expected, newqf.ColumnOrder(tc.order...)), out)
 		})
 	}
 }
 
-func sum(c []int) int {
-	result := 0
-	for _, v := range c {
-		result += v
-	}
-	return result
+func TestQFrame_Melt(t *testing.T) {
+	input := qframe.New(map[string]interface{}{
+		"ID": []int{1, 2},
+		"A":  []int{3, 4},
+		"B":  []float64{5.5, 6.5},
+		"C":  []string{"x", "y"}})
+
+	out := input.Melt([]string{"ID"}, []string{"A", "B"})
+	assertNotErr(t, out.Err)
+	expected := qframe.New(map[string]interface{}{
+		"ID":    []int{1, 2, 1, 2},
+		"name":  []string{"A", "A", "B", "B"},
+		"value": []float64{3, 4, 5.5, 6.5}},
+		newqf.ColumnOrder("ID", "name", "value"))
+	assertEquals(t, expected, out)
+
+	// Melt is the inverse of Pivot
+	pivoted := out.Pivot("ID", "name", "value", "sum")
+	assertEquals(t, qframe.New(map[string]interface{}{
+		"ID": []int{1, 2},
+		"A":  []float64{3, 4},
+		"B":  []float64{5.5, 6.5}},
+		newqf.ColumnOrder("ID", "A", "B")), pivoted)
+
+	out = input.Drop("A", "B").Melt([]string{"ID"}, nil)
+	assertNotErr(t, out.Err)
+	expected = qframe.New(map[string]interface{}{
+		"ID":    []int{1, 2},
+		"name":  []string{"C", "C"},
+		"value": []string{"x", "y"}},
+		newqf.ColumnOrder("ID", "name", "value"))
+	assertEquals(t, expected, out)
 }
 
-func TestQFrame_AggregateGroupByNull(t *testing.T) {
-	a, b := "a", "b"
-	for _, groupByNull := range []bool{false, true} {
-		for _, column := range []string{"COL1", "COL2", "COL3"} {
-			t.Run(fmt.Sprintf("%s %v", column, groupByNull), func(t *testing.T) {
-				input := qframe.New(map[string]interface{}{
-					"COL1": []*string{&a, &b, nil, &a, &b, nil},
-					"COL2": []*string{&a, &b, nil, &a, &b, nil},
-					"COL3": []float64{1, 2, math.NaN(), 1, 2, math.NaN()},
-					"COL4": []int{1, 2, 3, 10, 20, 30},
-				}, newqf.Enums(map[string][]string{"COL2": nil}))
+func TestQFrame_Window(t *testing.T) {
+	nan := math.NaN()
+	one, three := 1, 3
+	in := qframe.New(map[string]interface{}{
+		"GROUP": []string{"a", "b", "a", "a", "b"},
+		"PRICE": []int{3, 1, 1, 3, 2},
+		"QTY":   []float64{1, 2, 3, 4, nan}},
+		newqf.ColumnOrder("GROUP", "PRICE", "QTY"))
+
+	out := in.GroupBy(groupby.Columns("GROUP")).Sort(qframe.Order{Column: "PRICE"}).Window(
+		window.RowNumber("RN"),
+		window.Rank("RANK"),
+		window.DenseRank("DRANK"),
+		window.Lag("PRICE", 1, "PREV"),
+		window.Lead("QTY", 1, "NEXT"),
+		window.CumSum("QTY", "CUM"))
+	assertNotErr(t, out.Err)
 
-				col4 := []int{3, 30, 11, 22}
-				if groupByNull {
-					// Here we expect the nil/NaN columns to have been aggregated together
-					col4 = []int{33, 11, 22}
-				}
-				expected := qframe.New(map[string]interface{}{"COL4": col4})
+	expected := qframe.New(map[string]interface{}{
+		"GROUP": []string{"a", "b", "a", "a", "b"},
+		"PRICE": []int{3, 1, 1, 3, 2},
+		"QTY":   []float64{1, 2, 3, 4, nan},
+		"RN":    []int{2, 1, 1, 3, 2},
+		"RANK":  []int{2, 1, 1, 2, 2},
+		"DRANK": []int{2, 1, 1, 2, 2},
+		"PREV":  []*int{&one, nil, nil, &three, &one},
+		"NEXT":  []float64{4, nan, 1, nan, nan},
+		"CUM":   []float64{4, 2, 3, 8, nan}},
+		newqf.ColumnOrder("GROUP", "PRICE", "QTY", "RN", "RANK", "DRANK", "PREV", "NEXT", "CUM"))
+	assertEquals(t, expected, out)
+}
 
-				out := input.GroupBy(groupby.Columns(column), groupby.Null(groupByNull)).Aggregate(qframe.Aggregation{Fn: sum, Column: "COL4"})
-				assertEquals(t, expected, out.Sort(colNamesToOrders(column, "COL4")...).Select("COL4"))
-			})
-		}
+func TestQFrame_WindowFiltered(t *testing.T) {
+	in := qframe.New(map[string]interface{}{
+		"GROUP": []string{"a", "b", "a", "a", "b", "a"},
+		"VAL":   []int{1, 2, 3, 4, 5, 6}},
+		newqf.ColumnOrder("GROUP", "VAL"))
+
+	// No sorting, the rows are processed in the order of the QFrame
+	out := in.Filter(qframe.Filter{Column: "VAL", Comparator: ">", Arg: 1}).
+		GroupBy(groupby.Columns("GROUP")).
+		Window(window.CumSum("VAL", "CUM"), window.Lag("VAL", 0, "LAG"), window.RowNumber("RN"))
+	assertNotErr(t, out.Err)
+
+	expected := qframe.New(map[string]interface{}{
+		"GROUP": []string{"b", "a", "a", "b", "a"},
+		"VAL":   []int{2, 3, 4, 5, 6},
+		"CUM":   []int{2, 3, 7, 7, 13},
+		"LAG":   []int{2, 3, 4, 5, 6},
+		"RN":    []int{1, 1, 2, 2, 3}},
+		newqf.ColumnOrder("GROUP", "VAL", "CUM", "LAG", "RN"))
+	assertEquals(t, expected, out)
+}
+
+func TestQFrame_WindowErrors(t *testing.T) {
+	in := qframe.New(map[string]interface{}{
+		"GROUP": []string{"a", "b"},
+		"VAL":   []int{1, 2}})
+
+	table := []struct {
+		name string
+		qf   qframe.QFrame
+		err  string
+	}{
+		{
+			name: "unknown column",
+			qf:   in.GroupBy(groupby.Columns("GROUP")).Window(window.Lag("FOO", 1, "BAR")),
+			err:  "unknown column"},
+		{
+			name: "unknown sort column",
+			qf:   in.GroupBy(groupby.Columns("GROUP")).Sort(qframe.Order{Column: "FOO"}).Window(window.RowNumber("RN")),
+			err:  "unknown column"},
+		{
+			name: "cumsum on string column",
+			qf:   in.GroupBy(groupby.Columns("VAL")).Window(window.CumSum("GROUP", "CUM")),
+			err:  "not supported for string column"},
+		{
+			name: "negative offset",
+			qf:   in.GroupBy(groupby.Columns("GROUP")).Window(window.Lead("VAL", -1, "LEAD")),
+			err:  "offset must not be negative"},
+		{
+			name: "invalid destination column name",
+			qf:   in.GroupBy(groupby.Columns("GROUP")).Window(window.RowNumber("$RN")),
+			err:  "must not start with $"},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			assertErr(t, tc.qf.Err, tc.err)
+		})
 	}
 }
 
@@ -1826,7 +4214,7 @@ func TestQFrame_NewWithConstantVal(t *testing.T) {
 }
 
 func TestQFrame_NewErrors(t *testing.T) {
-	longCol := make([]string, 256)
+	longCol := make([]string, 65536)
 	for i := range longCol {
 		longCol[i] = fmt.Sprintf("%d", i)
 	}
@@ -1869,7 +4257,7 @@ func TestQFrame_NewErrors(t *testing.T) {
 			configs: []newqf.ConfigFunc{newqf.ColumnOrder("COL1", "COL3")},
 			err:     `column "COL3" in column order does not exist`},
 		{
-			input: map[string]interface{}{"COL1": []int8{1}},
+			input: map[string]interface{}{"COL1": []complex64{1}},
 			err:   `unknown column data type`},
 		{
 			input: map[string]interface{}{"COL1": []int{1}, "COL2": []int{2, 3}},
@@ -1958,6 +4346,50 @@ func TestQFrame_OperationErrors(t *testing.T) {
 				return f.GroupBy(groupby.Columns("COL1")).Aggregate(qframe.Aggregation{Fn: "sum", Column: "COL1"}).Err
 			},
 			err: "cannot aggregate on column that is part of group by"},
+		{
+			name: "Aggregate using quantile out of range",
+			fn: func(f qframe.QFrame) error {
+				return f.GroupBy(groupby.Columns("COL1")).Aggregate(qframe.Aggregation{Fn: "quantile(1.5)", Column: "COL2"}).Err
+			},
+			err: "quantile must be in the range [0, 1]"},
+		{
+			name: "Aggregate using malformed quantile",
+			fn: func(f qframe.QFrame) error {
+				return f.GroupBy(groupby.Columns("COL1")).Aggregate(qframe.Aggregation{Fn: "quantile(x)", Column: "COL2"}).Err
+			},
+			err: "invalid quantile in quantile(x)"},
+		{
+			name: "Rolling using malformed quantile",
+			fn: func(f qframe.QFrame) error {
+				return f.Rolling("quantile(0.5", "COL3", "COL2").Err
+			},
+			err: "not defined"},
+		{
+			name: "Rolling using quantile out of range",
+			fn: func(f qframe.QFrame) error {
+				return f.Rolling("quantile(-0.5)", "COL3", "COL2").Err
+			},
+			err: "quantile must be in the range [0, 1], was -0.5"},
+		{
+			name: "Rolling using built in function with different result type",
+			fn: func(f qframe.QFrame) error {
+				return f.Rolling("median", "COL3", "COL2").Err
+			},
+			err: "different result type"},
+		{
+			name: "Aggregate to the same destination column twice is not allowed",
+			fn: func(f qframe.QFrame) error {
+				return f.GroupBy(groupby.Columns("COL1")).Aggregate(
+					qframe.Aggregation{Fn: "sum", Column: "COL2", As: "X"},
+					qframe.Aggregation{Fn: "count", Column: "COL2", As: "X"}).Err
+			},
+			err: "is already an aggregate: X"},
+		{
+			name: "Aggregate to invalid destination column name",
+			fn: func(f qframe.QFrame) error {
+				return f.GroupBy(groupby.Columns("COL1")).Aggregate(qframe.Aggregation{Fn: "sum", Column: "COL2", As: "$X"}).Err
+			},
+			err: "must not start with $"},
 		{
 			name:    "Filter using unknown operation, enum",
 			input:   map[string]interface{}{"COL1": []string{"a", "b"}},
@@ -2029,6 +4461,59 @@ func TestQFrame_OperationErrors(t *testing.T) {
 			input: map[string]interface{}{"COL1": []string{"a"}},
 			fn:    func(f qframe.QFrame) error { return f.Sort(qframe.Order{Column: "COL2"}).Err },
 			err:   "unknown column"},
+		{
+			name: "Join with invalid join type",
+			fn: func(f qframe.QFrame) error {
+				return f.Join(f, join.How("cross")).Err
+			},
+			err: "How must be inner/left/right/outer"},
+		{
+			name: "Join without common columns",
+			fn: func(f qframe.QFrame) error {
+				return f.Join(qframe.New(map[string]interface{}{"COL3": []int{1}})).Err
+			},
+			err: "no common columns"},
+		{
+			name: "Join on unknown column",
+			fn: func(f qframe.QFrame) error {
+				return f.Join(f, join.Columns("COL3")).Err
+			},
+			err: "unknown column"},
+		{
+			name: "Join on columns of different types",
+			fn: func(f qframe.QFrame) error {
+				return f.Join(qframe.New(map[string]interface{}{"COL1": []float64{1}})).Err
+			},
+			err: "type mismatch"},
+		{
+			name: "Join with clashing suffixed column name",
+			fn: func(f qframe.QFrame) error {
+				other := qframe.New(map[string]interface{}{"COL1": []int{1}, "COL2": []int{2}, "COL2_left": []int{3}})
+				return f.Join(other, join.Columns("COL1")).Err
+			},
+			err: "duplicate column name"},
+		{
+			name: "Pivot with same index and columns column",
+			fn:   func(f qframe.QFrame) error { return f.Pivot("COL1", "COL1", "COL2", "sum").Err },
+			err:  "must be different columns"},
+		{
+			name: "Pivot with unknown column",
+			fn:   func(f qframe.QFrame) error { return f.Pivot("COL1", "COL3", "COL2", "sum").Err },
+			err:  "unknown column"},
+		{
+			name:  "Pivot with invalid column name",
+			input: map[string]interface{}{"COL1": []int{1}, "COL2": []string{"$a"}, "COL3": []int{1}},
+			fn:    func(f qframe.QFrame) error { return f.Pivot("COL1", "COL2", "COL3", "sum").Err },
+			err:   "must not start with $"},
+		{
+			name:  "Melt with value columns of different types",
+			input: map[string]interface{}{"COL1": []int{1}, "COL2": []string{"a"}, "COL3": []int{1}},
+			fn:    func(f qframe.QFrame) error { return f.Melt([]string{"COL3"}, nil).Err },
+			err:   "type mismatch"},
+		{
+			name: "Melt with id column as value column",
+			fn:   func(f qframe.QFrame) error { return f.Melt([]string{"COL1"}, []string{"COL1", "COL2"}).Err },
+			err:  "both id and value column"},
 		{
 			name:  "Get view for wrong type",
 			input: map[string]interface{}{"COL1": []string{"a"}},
@@ -2161,6 +4646,26 @@ func TestQFrame_StringView(t *testing.T) {
 	assertTrue(t, (*v.ItemAt(2) == *s[2]) && (*s[2] == *expected[2]))
 }
 
+func TestQFrame_TimeView(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	t1, t2 := time.Date(2019, 1, 1, 10, 0, 0, 0, loc), time.Date(2019, 1, 2, 10, 0, 0, 0, loc)
+	input := qframe.New(map[string]interface{}{"COL1": []*time.Time{&t2, nil, &t1}})
+	input = input.Sort(qframe.Order{Column: "COL1"})
+
+	v, err := input.TimeView("COL1")
+	assertNotErr(t, err)
+
+	s := v.Slice()
+	assertTrue(t, v.Len() == 3)
+	assertTrue(t, v.IsNull(0) && s[0] == nil)
+	assertTrue(t, !v.IsNull(1) && v.ItemAt(1) == t1 && *s[1] == t1)
+	assertTrue(t, !v.IsNull(2) && v.ItemAt(2) == t2 && *s[2] == t2)
+	assertTrue(t, v.ItemAt(1).Location() == loc)
+
+	_, err = input.IntView("COL1")
+	assertErr(t, err, "invalid column type")
+}
+
 func TestQFrame_EnumView(t *testing.T) {
 	a, b := "a", "b"
 	input := qframe.New(map[string]interface{}{"COL1": []*string{&a, nil, &b}}, newqf.Enums(map[string][]string{"COL1": {"a", "b"}}))
@@ -0,0 +1,287 @@
+package qframe
+
+import (
+	"fmt"
+
+	"github.com/tobgu/qframe/config/groupby"
+	"github.com/tobgu/qframe/filter"
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/grouper"
+	"github.com/tobgu/qframe/internal/icolumn"
+	"github.com/tobgu/qframe/internal/index"
+	"github.com/tobgu/qframe/internal/scolumn"
+	qfstrings "github.com/tobgu/qframe/internal/strings"
+	"github.com/tobgu/qframe/qerrors"
+	"github.com/tobgu/qframe/types"
+)
+
+// joinIndices returns, for every row in qf, the positions in other with equal values in colName.
+// Null never matches.
+func (qf QFrame) joinIndices(operation string, other QFrame, colName string) ([]index.Int, error) {
+	keyColumns, err := qf.keyColumns(operation, other, []string{colName})
+	if err != nil {
+		return nil, err
+	}
+
+	qfLen := uint32(qf.Len())
+	otherIx := make(index.Int, other.Len())
+	for i := range otherIx {
+		otherIx[i] = qfLen + uint32(i)
+	}
+
+	comparables := []column.Comparable{keyColumns[0].Comparable(false, false, false)}
+	matches, _, _ := grouper.Join(index.NewAscending(qfLen), otherIx, comparables)
+	for _, match := range matches {
+		for i := range match {
+			match[i] -= qfLen
+		}
+	}
+
+	return matches, nil
+}
+
+// Pivot reshapes the QFrame from long to wide format. The resulting QFrame has one row for each distinct
+// value in the index column and one column for each distinct value in the columns column, both sorted
+// in ascending order. The cells are populated with the values column aggregated using agg over all rows
+// with the corresponding index and columns values. Any aggregation accepted by Grouper.Aggregate may be used.
+//
+// Rows with null in the index or columns column are ignored. Combinations of index and columns values
+// not present in the QFrame are null in the result.
+//
+// Time complexity O(m * n) where m = number of distinct values in the columns column, n = number of rows.
+func (qf QFrame) Pivot(indexCol, columnsCol, valuesCol string, agg types.SliceFuncOrBuiltInId) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	if err := qf.checkColumns("Pivot", []string{indexCol, columnsCol, valuesCol}); err != nil {
+		return qf.withErr(err)
+	}
+
+	if indexCol == columnsCol || indexCol == valuesCol || columnsCol == valuesCol {
+		return qf.withErr(qerrors.New("Pivot", "index, columns and values must be different columns"))
+	}
+
+	filtered := qf.Filter(And(
+		Filter{Column: indexCol, Comparator: filter.IsNotNull},
+		Filter{Column: columnsCol, Comparator: filter.IsNotNull}))
+
+	grouped := filtered.GroupBy(groupby.Columns(indexCol, columnsCol)).Aggregate(Aggregation{Fn: agg, Column: valuesCol})
+	if grouped.Err != nil {
+		return qf.withErr(qerrors.Propagate("Pivot", grouped.Err))
+	}
+
+	rows := grouped.Distinct(groupby.Columns(indexCol)).Select(indexCol).Sort(Order{Column: indexCol})
+	cols := grouped.Distinct(groupby.Columns(columnsCol)).Select(columnsCol).Sort(Order{Column: columnsCol})
+	rowMatches, err := rows.joinIndices("Pivot", grouped, indexCol)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	colMatches, err := cols.joinIndices("Pivot", grouped, columnsCol)
+	if err != nil {
+		return qf.withErr(err)
+	}
+
+	colPositions := make([]int, grouped.Len())
+	for pos, match := range colMatches {
+		for _, i := range match {
+			colPositions[i] = pos
+		}
+	}
+
+	subsetIndices := make([]index.Int, cols.Len())
+	for i := range subsetIndices {
+		ix := make(index.Int, rows.Len())
+		for j := range ix {
+			ix[j] = nullRow
+		}
+		subsetIndices[i] = ix
+	}
+
+	for pos, match := range rowMatches {
+		for _, i := range match {
+			subsetIndices[colPositions[i]][pos] = i
+		}
+	}
+
+	valueCol := grouped.columnsByName[valuesCol].Column
+
+	newColumns := make([]namedColumn, 0, cols.Len()+1)
+	newColumnsByName := make(map[string]namedColumn, cols.Len()+1)
+	rowCol := rows.columnsByName[indexCol]
+	rowCol.Column = rowCol.Subset(rows.index)
+	rowCol.pos = 0
+	newColumns = append(newColumns, rowCol)
+	newColumnsByName[indexCol] = rowCol
+
+	nameCol := cols.columnsByName[columnsCol].Column
+	for i, ix := range cols.index {
+		name := nameCol.StringAt(ix, "")
+		if err := qfstrings.CheckName(name); err != nil {
+			return qf.withErr(qerrors.Propagate("Pivot", err))
+		}
+
+		if _, ok := newColumnsByName[name]; ok {
+			return qf.withErr(qerrors.New("Pivot", "duplicate column name in result: %s", name))
+		}
+
+		col, err := nullableSubset(valueCol, subsetIndices[i])
+		if err != nil {
+			return qf.withErr(qerrors.Propagate(fmt.Sprintf("Pivot column %s", name), err))
+		}
+
+		newCol := namedColumn{Column: col, name: name, pos: len(newColumns)}
+		newColumns = append(newColumns, newCol)
+		newColumnsByName[name] = newCol
+	}
+
+	return QFrame{columns: newColumns, columnsByName: newColumnsByName, index: index.NewAscending(uint32(rows.Len()))}
+}
+
+// Crosstab returns a contingency table with the number of rows for each combination of values in
+// rowCol and colCol. The resulting QFrame has one row for each distinct value in rowCol and one int
+// column for each distinct value in colCol, both sorted in ascending order. Combinations that do
+// not occur in the QFrame have count 0.
+//
+// Rows with null in rowCol or colCol are ignored.
+//
+// Time complexity O(m * n) where m = number of distinct values in colCol, n = number of rows.
+func (qf QFrame) Crosstab(rowCol, colCol string) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	if err := qf.checkColumns("Crosstab", []string{rowCol, colCol}); err != nil {
+		return qf.withErr(err)
+	}
+
+	if rowCol == colCol {
+		return qf.withErr(qerrors.New("Crosstab", "row and column must be different columns"))
+	}
+
+	// Name of the intermediate column holding the counts, it is not part of the result
+	countCol := "count"
+	for countCol == rowCol || countCol == colCol {
+		countCol += "_"
+	}
+
+	table := qf.Filter(And(
+		Filter{Column: rowCol, Comparator: filter.IsNotNull},
+		Filter{Column: colCol, Comparator: filter.IsNotNull})).
+		GroupBy(groupby.Columns(rowCol, colCol)).
+		Aggregate(Aggregation{Fn: "count", Column: rowCol, As: countCol}).
+		Pivot(rowCol, colCol, countCol, "sum")
+	if table.Err != nil {
+		return qf.withErr(qerrors.Propagate("Crosstab", table.Err))
+	}
+
+	// Pivot leaves missing combinations null, replace them with 0
+	for _, col := range table.columns[1:] {
+		view, err := table.IntView(col.name)
+		if err != nil {
+			return qf.withErr(qerrors.Propagate("Crosstab", err))
+		}
+
+		table = table.setColumn(col.name, icolumn.New(view.Slice()))
+	}
+
+	return table
+}
+
+// Melt reshapes the QFrame from wide to long format. This is the inverse of Pivot.
+//
+// For every column in valueCols the rows of the QFrame are repeated with the id columns kept as is, the name of
+// the value column in a string column named "name" and the content of the value column in a column named "value".
+// The value columns must be of the same type with the exception of int and float columns that are combined
+// into a float column. If no value columns are given all columns not in idCols are used.
+//
+// Time complexity O(m * n) where m = number of value columns, n = number of rows.
+func (qf QFrame) Melt(idCols, valueCols []string) QFrame {
+	if qf.Err != nil {
+		return qf
+	}
+
+	if err := qf.checkColumns("Melt", idCols); err != nil {
+		return qf.withErr(err)
+	}
+
+	if err := qf.checkColumns("Melt", valueCols); err != nil {
+		return qf.withErr(err)
+	}
+
+	idSet := qfstrings.NewStringSet(idCols)
+	if len(valueCols) == 0 {
+		for _, col := range qf.columns {
+			if !idSet.Contains(col.name) {
+				valueCols = append(valueCols, col.name)
+			}
+		}
+	}
+
+	if len(valueCols) == 0 {
+		return qf.withErr(qerrors.New("Melt", "no value columns"))
+	}
+
+	for _, col := range valueCols {
+		if idSet.Contains(col) {
+			return qf.withErr(qerrors.New("Melt", "column %s is both id and value column", col))
+		}
+	}
+
+	for _, col := range idCols {
+		if col == "name" || col == "value" {
+			return qf.withErr(qerrors.New("Melt", "id column name clashes with result column: %s", col))
+		}
+	}
+
+	valueType := qf.columnsByName[valueCols[0]].DataType()
+	for _, col := range valueCols[1:] {
+		dataType := qf.columnsByName[col].DataType()
+		if dataType == types.Float && valueType == types.Int || dataType == types.Int && valueType == types.Float {
+			valueType = types.Float
+		} else if dataType != valueType {
+			return qf.withErr(qerrors.New("Melt", "type mismatch for value column %s, %s != %s", col, valueType, dataType))
+		}
+	}
+
+	repeatedIx := make(index.Int, 0, len(valueCols)*qf.Len())
+	names := make([]string, 0, len(valueCols)*qf.Len())
+	parts := make([]column.Column, 0, len(valueCols))
+	for _, name := range valueCols {
+		repeatedIx = append(repeatedIx, qf.index...)
+		for range qf.index {
+			names = append(names, name)
+		}
+
+		part := qf.columnsByName[name].Subset(qf.index)
+		if part.DataType() == types.Int && valueType == types.Float {
+			part = intToFloat(part)
+		}
+		parts = append(parts, part)
+	}
+
+	valueCol, err := parts[0].Append(parts[1:]...)
+	if err != nil {
+		return qf.withErr(qerrors.Propagate("Melt", err))
+	}
+
+	newColumns := make([]namedColumn, 0, len(idCols)+2)
+	newColumnsByName := make(map[string]namedColumn, len(idCols)+2)
+	for _, name := range idCols {
+		col := qf.columnsByName[name]
+		col.Column = col.Subset(repeatedIx)
+		col.pos = len(newColumns)
+		newColumns = append(newColumns, col)
+		newColumnsByName[name] = col
+	}
+
+	for _, col := range []namedColumn{
+		{Column: scolumn.NewStrings(names), name: "name", pos: len(newColumns)},
+		{Column: valueCol, name: "value", pos: len(newColumns) + 1}} {
+		newColumns = append(newColumns, col)
+		newColumnsByName[col.name] = col
+	}
+
+	return QFrame{columns: newColumns, columnsByName: newColumnsByName, index: index.NewAscending(uint32(len(names)))}
+}
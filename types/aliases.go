@@ -13,10 +13,18 @@ DataSlice can be a slice of any of the supported data types.
 
 The following types are currently supported:
 	[]bool
+	[]*bool
 	[]float64
 	[]int
+	[]*int
 	[]string
 	[]*string
+	[]time.Time
+	[]*time.Time
+	[]decimal.Decimal
+	[]*decimal.Decimal
+
+Nil pointers represent null values.
 */
 type DataSlice = interface{}
 
@@ -29,6 +37,12 @@ For example:
 	func(x []*string) *string
 	func(x []bool) bool
 
+When used in aggregations the function may also return a value of one of the other supported
+types, producing a column of that type. Eg. an average of an int column or the number of distinct
+values in a string column:
+	func(x []int) float64
+	func(x []*string) int
+
 Or it can be a string identifying a built in function.
 
 For example:
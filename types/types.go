@@ -8,7 +8,8 @@ const (
 	// This is mainly used to indicate that the type of a column should be auto detected.
 	None DataType = ""
 
-	// Int translates into the Go int type. Missing values cannot be represented explicitly.
+	// Int translates into the Go int type. Missing values are kept track of separately from
+	// the data, nil represents a missing value when creating a column from a []*int.
 	Int = "int"
 
 	// String translates into the Go *string type. nil represents a missing value.
@@ -19,13 +20,44 @@ const (
 	// Float translates into the Go float64 type. NaN represents a missing value.
 	Float = "float"
 
-	// Bool translates into the Go bool type. Missing values cannot be represented explicitly.
+	// Bool translates into the Go bool type. Missing values are kept track of separately from
+	// the data, nil represents a missing value when creating a column from a []*bool.
 	Bool = "bool"
 
 	// Enum translates into the Go *string type. nil represents a missing value.
-	// An enum column can, at most, have 254 distinct values.
+	// An enum column can, at most, have 65535 distinct values. Columns with up to 255 distinct
+	// values use one byte per element, larger columns use two.
 	Enum = "enum"
 
+	// Time translates into the Go time.Time type. Values are stored as nanosecond instants,
+	// limiting them to the years 1678 - 2262, together with a single location for the column.
+	// Missing values are kept track of separately from the data, nil represents a missing
+	// value when creating a column from a []*time.Time.
+	Time = "time"
+
+	// Decimal translates into the decimal.Decimal type, an exact fixed point number suitable for
+	// monetary amounts. All values of a column share the same scale, the largest scale of the values
+	// the column was created from. Missing values are kept track of separately from the data, nil
+	// represents a missing value when creating a column from a []*decimal.Decimal.
+	Decimal = "decimal"
+
+	// Int8, Int16, Int32, Uint8, Uint16 and Uint32 store integers using less memory than Int.
+	// They translate into the corresponding Go types when accessed through views. All other
+	// operations, filters, aggregations, functions applied, etc, work on the values widened to
+	// int and produce int columns where the result is a column. Missing values are kept track of
+	// separately from the data.
+	Int8   = "int8"
+	Int16  = "int16"
+	Int32  = "int32"
+	Uint8  = "uint8"
+	Uint16 = "uint16"
+	Uint32 = "uint32"
+
+	// Float32 stores floats using less memory than Float. It translates into the Go float32 type when
+	// accessed through views. All other operations work on the values widened to float64 and produce
+	// float columns where the result is a column. NaN represents a missing value.
+	Float32 = "float32"
+
 	// Undefined represents an unspecified data type.
 	// This is used for zero length columns where the datatype could not be identified.
 	Undefined DataType = "Undefined"
@@ -40,6 +72,8 @@ const (
 	FunctionTypeFloat
 	FunctionTypeBool
 	FunctionTypeString
+	FunctionTypeTime
+	FunctionTypeDecimal
 )
 
 func (t FunctionType) String() string {
@@ -52,6 +86,10 @@ func (t FunctionType) String() string {
 		return "String function"
 	case FunctionTypeFloat:
 		return "Float function"
+	case FunctionTypeTime:
+		return "Time function"
+	case FunctionTypeDecimal:
+		return "Decimal function"
 	case FunctionTypeUndefined:
 		return "Undefined type function"
 	default:
@@ -0,0 +1,170 @@
+package qframe
+
+import (
+	"math"
+
+	"github.com/tobgu/qframe/internal/column"
+	"github.com/tobgu/qframe/internal/fcolumn"
+	"github.com/tobgu/qframe/internal/icolumn"
+	"github.com/tobgu/qframe/internal/index"
+	"github.com/tobgu/qframe/internal/nulls"
+	"github.com/tobgu/qframe/qerrors"
+	"github.com/tobgu/qframe/window"
+)
+
+func peers(comparables []column.Comparable, i, j uint32) bool {
+	for _, c := range comparables {
+		if c.Compare(i, j) != column.Equal {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (g Grouper) rankWindow(fn window.Function, size int) column.Column {
+	comparables := make([]column.Comparable, 0, len(g.orders))
+	for _, o := range g.orders {
+		comparables = append(comparables, g.columnsByName[o.Column].Comparable(o.Reverse, true, o.NullLast))
+	}
+
+	data := make([]int, size)
+	for _, ix := range g.indices {
+		rank, denseRank := 0, 0
+		for k, i := range ix {
+			if k == 0 || !peers(comparables, ix[k-1], i) {
+				rank = k + 1
+				denseRank++
+			}
+
+			switch fn.Kind {
+			case window.KindRowNumber:
+				data[i] = k + 1
+			case window.KindRank:
+				data[i] = rank
+			default:
+				data[i] = denseRank
+			}
+		}
+	}
+
+	return icolumn.New(data)
+}
+
+func (g Grouper) shiftWindow(fn window.Function, col column.Column, size int) (column.Column, error) {
+	if fn.Offset < 0 {
+		return nil, qerrors.New(fn.Kind, "offset must not be negative, was %d", fn.Offset)
+	}
+
+	offset := fn.Offset
+	if fn.Kind == window.KindLag {
+		offset = -offset
+	}
+
+	srcIx := index.NewAscending(uint32(size))
+	for _, ix := range g.indices {
+		for k, i := range ix {
+			if j := k + offset; j >= 0 && j < len(ix) {
+				srcIx[i] = ix[j]
+			} else {
+				srcIx[i] = nullRow
+			}
+		}
+	}
+
+	return nullableSubset(col, srcIx)
+}
+
+func (g Grouper) cumSumWindow(fn window.Function, col column.Column, size int) (column.Column, error) {
+	switch c := col.(type) {
+	case icolumn.Column:
+		data := make([]int, size)
+		var nullSet nulls.Set
+		for _, ix := range g.indices {
+			view, sum := c.View(ix), 0
+			for k, i := range ix {
+				if view.IsNull(k) {
+					if nullSet == nil {
+						nullSet = nulls.New(size)
+					}
+					nullSet.Add(i)
+				} else {
+					sum += view.ItemAt(k)
+					data[i] = sum
+				}
+			}
+		}
+		return icolumn.NewNullable(data, nullSet), nil
+	case fcolumn.Column:
+		data := make([]float64, size)
+		for _, ix := range g.indices {
+			view, sum := c.View(ix), 0.0
+			for k, i := range ix {
+				if v := view.ItemAt(k); math.IsNaN(v) {
+					data[i] = v
+				} else {
+					sum += v
+					data[i] = sum
+				}
+			}
+		}
+		return fcolumn.New(data), nil
+	default:
+		return nil, qerrors.New(fn.Kind, "not supported for %s column", col.DataType())
+	}
+}
+
+func (g Grouper) window(fn window.Function, size int) (column.Column, error) {
+	switch fn.Kind {
+	case window.KindRowNumber, window.KindRank, window.KindDenseRank:
+		return g.rankWindow(fn, size), nil
+	}
+
+	col, ok := g.columnsByName[fn.Column]
+	if !ok {
+		return nil, qerrors.New(fn.Kind, unknownCol(fn.Column))
+	}
+
+	switch fn.Kind {
+	case window.KindLag, window.KindLead:
+		return g.shiftWindow(fn, col.Column, size)
+	case window.KindCumSum:
+		return g.cumSumWindow(fn, col.Column, size)
+	default:
+		return nil, qerrors.New("Window", "unknown window function: %s", fn.Kind)
+	}
+}
+
+// Window applies the given window functions to all row groups in the Grouper. In contrast to
+// Aggregate all rows are kept, the result of each window function is written to a new column
+// in the same row. The rows are processed in the order given by Sort within each group while
+// the resulting QFrame keeps the row order of the QFrame that was grouped.
+//
+// See the window package for available window functions.
+//
+// Time complexity O(m*n) where m = number of window functions, n = number of rows.
+func (g Grouper) Window(fns ...window.Function) QFrame {
+	if g.Err != nil {
+		return QFrame{Err: g.Err}
+	}
+
+	qf := QFrame{columns: g.columns, columnsByName: g.columnsByName, index: g.index}
+	size := 0
+	if len(g.columns) > 0 {
+		size = g.columns[0].Len()
+	}
+
+	for _, fn := range fns {
+		col, err := g.window(fn, size)
+		if err != nil {
+			return qf.withErr(qerrors.Propagate("Window", err))
+		}
+
+		qf = qf.setColumn(fn.DstCol, col)
+		if qf.Err != nil {
+			return qf
+		}
+	}
+
+	return qf
+}
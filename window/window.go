@@ -0,0 +1,76 @@
+/*
+Package window contains window functions that can be used in Grouper.Window.
+
+Window functions compute a value for every row based on the other rows in the same group.
+Unlike aggregations they do not collapse the groups, the number of rows is kept intact.
+The rows within each group are processed in the order given by Grouper.Sort.
+*/
+package window
+
+// Kinds of window functions
+const (
+	KindRowNumber = "row_number"
+	KindRank      = "rank"
+	KindDenseRank = "dense_rank"
+	KindLag       = "lag"
+	KindLead      = "lead"
+	KindCumSum    = "cumsum"
+)
+
+// Function describes a window function.
+// It should be considered a private implementation detail and should never be
+// created or manipulated directly outside of the QFrame code. To create it
+// use the functions below.
+type Function struct {
+	// Kind is the type of window function.
+	Kind string
+
+	// Column is the name of the column that the function operates on.
+	// Empty for functions that only depend on the row order.
+	Column string
+
+	// Offset is the number of rows to look back or forward for lag and lead.
+	Offset int
+
+	// DstCol is the name of the column that the result is written to.
+	DstCol string
+}
+
+// RowNumber numbers the rows within each group, starting at 1.
+// The result is an int column.
+func RowNumber(dstCol string) Function {
+	return Function{Kind: KindRowNumber, DstCol: dstCol}
+}
+
+// Rank ranks the rows within each group, starting at 1. Rows that are equal with respect
+// to the columns sorted by share the same rank, leaving gaps in the ranks that follow.
+// The result is an int column.
+func Rank(dstCol string) Function {
+	return Function{Kind: KindRank, DstCol: dstCol}
+}
+
+// DenseRank works like Rank except that no gaps are left in the ranks following
+// rows that share the same rank.
+// The result is an int column.
+func DenseRank(dstCol string) Function {
+	return Function{Kind: KindDenseRank, DstCol: dstCol}
+}
+
+// Lag sets each row to the value of column offset rows before it within the group.
+// Rows without a preceding row at that offset are set to null.
+func Lag(column string, offset int, dstCol string) Function {
+	return Function{Kind: KindLag, Column: column, Offset: offset, DstCol: dstCol}
+}
+
+// Lead sets each row to the value of column offset rows after it within the group.
+// See Lag for details on null handling.
+func Lead(column string, offset int, dstCol string) Function {
+	return Function{Kind: KindLead, Column: column, Offset: offset, DstCol: dstCol}
+}
+
+// CumSum sets each row to the sum of column for all rows up to and including it within the group.
+// Supported for int and float columns. Null values, NaN for floats, are skipped and rows holding
+// them are set to null.
+func CumSum(column, dstCol string) Function {
+	return Function{Kind: KindCumSum, Column: column, DstCol: dstCol}
+}